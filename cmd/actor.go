@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	actorConfig dapr.ActorConfig
+
+	ActorCMD = &cobra.Command{
+		Use: "actor",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+)
+
+func init() {
+	ActorCMD.PersistentFlags().StringVarP(&actorConfig.RedisAddress, "redis-address", "", dapr.DefaultRedisAddress, "The address of Redis the actor state is read from")
+	ActorCMD.PersistentFlags().StringVarP(&actorConfig.RedisPassword, "redis-password", "", "", "The password of Redis the actor state is read from")
+	ActorCMD.PersistentFlags().StringVarP(&actorConfig.PlacementAddress, "placement-address", "", dapr.DefaultPlacementHealthAddress, "The address of the placement health server")
+	addClientFlags(ActorCMD, &actorConfig.ClientConfig)
+	RootCMD.AddCommand(ActorCMD)
+}
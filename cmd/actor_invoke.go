@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	actorInvokeConfig dapr.ActorInvokeConfig
+
+	ActorInvokeCMD = &cobra.Command{
+		Use:  "invoke <type> <id> <method>",
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			actorInvokeConfig.ActorConfig = actorConfig
+			actorInvokeConfig.ActorType = args[0]
+			actorInvokeConfig.ActorID = args[1]
+			actorInvokeConfig.Method = args[2]
+			ctx := context.Background()
+			return dapr.ActorInvoke(ctx, &actorInvokeConfig)
+		},
+	}
+)
+
+func init() {
+	ActorInvokeCMD.PersistentFlags().StringVarP(&actorInvokeConfig.Data, "data", "d", "", "The data to send")
+	ActorInvokeCMD.PersistentFlags().StringVarP(&actorInvokeConfig.DataFile, "data-file", "f", "", "The file to read the data to send from")
+	ActorCMD.AddCommand(ActorInvokeCMD)
+}
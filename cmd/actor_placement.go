@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	ActorPlacementCMD = &cobra.Command{
+		Use: "placement",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			return dapr.ActorPlacement(ctx, &actorConfig)
+		},
+	}
+)
+
+func init() {
+	ActorCMD.AddCommand(ActorPlacementCMD)
+}
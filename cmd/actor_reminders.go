@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	ActorRemindersCMD = &cobra.Command{
+		Use: "reminders",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+)
+
+func init() {
+	ActorCMD.AddCommand(ActorRemindersCMD)
+}
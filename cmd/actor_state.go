@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	ActorStateCMD = &cobra.Command{
+		Use:  "state <type> <id> [key]",
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := ""
+			if len(args) > 2 {
+				key = args[2]
+			}
+			ctx := context.Background()
+			return dapr.ActorState(ctx, &actorConfig, args[0], args[1], key)
+		},
+	}
+)
+
+func init() {
+	ActorCMD.AddCommand(ActorStateCMD)
+}
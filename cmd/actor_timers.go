@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	ActorTimersCMD = &cobra.Command{
+		Use: "timers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+)
+
+func init() {
+	ActorCMD.AddCommand(ActorTimersCMD)
+}
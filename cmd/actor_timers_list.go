@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	ActorTimersListCMD = &cobra.Command{
+		Use:     "list <type> [id]",
+		Aliases: []string{"ls"},
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			actorID := ""
+			if len(args) > 1 {
+				actorID = args[1]
+			}
+			ctx := context.Background()
+			return dapr.ActorTimers(ctx, &actorConfig, args[0], actorID)
+		},
+	}
+)
+
+func init() {
+	ActorTimersCMD.AddCommand(ActorTimersListCMD)
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	BindingCMD = &cobra.Command{
+		Use: "binding",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+)
+
+func init() {
+	RootCMD.AddCommand(BindingCMD)
+}
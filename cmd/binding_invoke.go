@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	bindingInvokeConfig dapr.BindingInvokeConfig
+
+	BindingInvokeCMD = &cobra.Command{
+		Use:  "invoke <name> <operation>",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bindingInvokeConfig.Name = args[0]
+			bindingInvokeConfig.Operation = args[1]
+			ctx := context.Background()
+			return dapr.BindingInvoke(ctx, &bindingInvokeConfig)
+		},
+	}
+)
+
+func init() {
+	BindingInvokeCMD.PersistentFlags().StringVarP(&bindingInvokeConfig.Data, "data", "d", "", "The data to send")
+	BindingInvokeCMD.PersistentFlags().StringVarP(&bindingInvokeConfig.DataFile, "data-file", "f", "", "The file to read the data to send from")
+	BindingInvokeCMD.PersistentFlags().StringArrayVarP(&bindingInvokeConfig.Metadata, "metadata", "m", []string{}, "A metadata as key=value passed to the binding, can be given multiple times")
+	BindingInvokeCMD.PersistentFlags().StringVarP(&bindingInvokeConfig.HTTPURL, "http-url", "", "", "Invoke a new HTTP binding to this URL through a temporary sidecar instead of an installed binding")
+	addClientFlags(BindingInvokeCMD, &bindingInvokeConfig.ClientConfig)
+	BindingCMD.AddCommand(BindingInvokeCMD)
+}
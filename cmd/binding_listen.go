@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	bindingListenConfig dapr.BindingListenConfig
+
+	BindingListenCMD = &cobra.Command{
+		Use:  "listen <name>",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bindingListenConfig.Name = args[0]
+			ctx := context.Background()
+			return dapr.BindingListen(ctx, &bindingListenConfig)
+		},
+	}
+)
+
+func init() {
+	BindingListenCMD.PersistentFlags().StringVarP(&bindingListenConfig.AppID, "app-id", "a", "", "The id of the temporary app, otherwise generated")
+	BindingListenCMD.PersistentFlags().StringVarP(&bindingListenConfig.Cron, "cron", "", "", "Listen to a new cron binding with this schedule, e.g. @every 5s, instead of an installed one")
+	BindingListenCMD.PersistentFlags().StringVarP(&bindingListenConfig.Output, "output", "o", dapr.LogOutputText, "The output format. Valid values are: text, json")
+	BindingCMD.AddCommand(BindingListenCMD)
+}
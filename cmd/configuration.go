@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	configurationConfig dapr.ConfigurationConfig
+
+	ConfigurationCMD = &cobra.Command{
+		Use: "configuration",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+)
+
+func init() {
+	addClientFlags(ConfigurationCMD, &configurationConfig.ClientConfig)
+	RootCMD.AddCommand(ConfigurationCMD)
+}
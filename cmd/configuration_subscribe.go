@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	ConfigurationSubscribeCMD = &cobra.Command{
+		Use:  "subscribe <store> <key>...",
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configurationConfig.StoreName = args[0]
+			configurationConfig.Keys = args[1:]
+			ctx := context.Background()
+			return dapr.ConfigurationSubscribe(ctx, &configurationConfig)
+		},
+	}
+)
+
+func init() {
+	ConfigurationCMD.AddCommand(ConfigurationSubscribeCMD)
+}
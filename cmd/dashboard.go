@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"context"
+
 	"github.com/spf13/cobra"
 	"github.com/yamajik/kess/dapr"
 )
@@ -12,8 +14,8 @@ var (
 		Use:     "dashboard",
 		Aliases: []string{"web"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			dapr.DashboardRun(&dashboardRunConfig)
-			return nil
+			ctx := context.Background()
+			return dapr.DashboardRun(ctx, &dashboardRunConfig)
 		},
 	}
 )
@@ -21,5 +21,7 @@ var (
 )
 
 func init() {
+	DockerCMD.PersistentFlags().StringVarP(&runtimeConfig.Docker.Pull, "pull", "", runtimes.DefaultDockerRuntimePull, "The image pull policy. Valid values are: always, missing, never")
+	DockerCMD.PersistentFlags().StringVarP(&runtimeConfig.Docker.RegistryAuth, "registry-auth", "", "", "The registry credentials as username:password or base64 encoded auth config, otherwise read from docker config")
 	RootCMD.AddCommand(DockerCMD)
 }
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	DockerBindingCMD = &cobra.Command{
+		Use: "binding",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+)
+
+func init() {
+	DockerCMD.AddCommand(DockerBindingCMD)
+}
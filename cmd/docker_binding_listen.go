@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+	"github.com/yamajik/kess/runtimes"
+)
+
+var (
+	dockerBindingListenOptions runtimes.RuntimeBindingListenOptions
+
+	DockerBindingListenCMD = &cobra.Command{
+		Use:  "listen <name>",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dockerBindingListenOptions.Name = args[0]
+			ctx := context.Background()
+			return runtime.BindingListen(ctx, dockerBindingListenOptions)
+		},
+	}
+)
+
+func init() {
+	DockerBindingListenCMD.PersistentFlags().StringVarP(&dockerBindingListenOptions.AppID, "app-id", "a", "", "The id of the temporary app, otherwise generated")
+	DockerBindingListenCMD.PersistentFlags().StringVarP(&dockerBindingListenOptions.Cron, "cron", "", "", "Listen to a new cron binding with this schedule, e.g. @every 5s, instead of an installed one")
+	DockerBindingListenCMD.PersistentFlags().StringVarP(&dockerBindingListenOptions.Output, "output", "o", dapr.LogOutputText, "The output format. Valid values are: text, json")
+	DockerBindingCMD.AddCommand(DockerBindingListenCMD)
+}
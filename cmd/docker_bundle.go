@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	DockerBundleCMD = &cobra.Command{
+		Use: "bundle",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+)
+
+func init() {
+	DockerCMD.AddCommand(DockerBundleCMD)
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/runtimes"
+)
+
+var (
+	dockerBundleLoadOptions runtimes.RuntimeBundleLoadOptions
+
+	DockerBundleLoadCMD = &cobra.Command{
+		Use:     "load",
+		Aliases: []string{"import"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			return runtime.BundleLoad(ctx, dockerBundleLoadOptions)
+		},
+	}
+)
+
+func init() {
+	DockerBundleLoadCMD.PersistentFlags().StringVarP(&dockerBundleLoadOptions.File, "input", "i", runtimes.DefaultDockerRuntimeBundleFile, "The bundle file to read")
+	DockerBundleCMD.AddCommand(DockerBundleLoadCMD)
+}
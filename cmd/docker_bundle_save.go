@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/runtimes"
+)
+
+var (
+	dockerBundleSaveOptions runtimes.RuntimeBundleSaveOptions
+
+	DockerBundleSaveCMD = &cobra.Command{
+		Use:     "save",
+		Aliases: []string{"export"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			return runtime.BundleSave(ctx, dockerBundleSaveOptions)
+		},
+	}
+)
+
+func init() {
+	DockerBundleSaveCMD.PersistentFlags().StringVarP(&dockerBundleSaveOptions.File, "output", "o", runtimes.DefaultDockerRuntimeBundleFile, "The bundle file to write")
+	DockerBundleSaveCMD.PersistentFlags().StringVarP(&dockerBundleSaveOptions.RuntimeVersion, "runtime-version", "", "", "The version of the Dapr runtime images to save, otherwise the installed version")
+	DockerBundleCMD.AddCommand(DockerBundleSaveCMD)
+}
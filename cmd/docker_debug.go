@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/runtimes"
+)
+
+var (
+	dockerDebugOptions runtimes.RuntimeDebugOptions
+
+	DockerDebugCMD = &cobra.Command{
+		Use:  "debug <AppID> [-- cmd...]",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dockerDebugOptions.AppID = args[0]
+			dockerDebugOptions.Cmd = args[1:]
+			ctx := context.Background()
+			return runtime.Debug(ctx, dockerDebugOptions)
+		},
+	}
+)
+
+func init() {
+	DockerDebugCMD.PersistentFlags().BoolVarP(&dockerDebugOptions.Tty, "tty", "t", true, "Allocate a TTY when stdin is a terminal")
+	DockerCMD.AddCommand(DockerDebugCMD)
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/runtimes"
+)
+
+var (
+	dockerExecOptions runtimes.RuntimeExecOptions
+
+	DockerExecCMD = &cobra.Command{
+		Use:  "exec <AppID> [-- cmd...]",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dockerExecOptions.AppID = args[0]
+			dockerExecOptions.Cmd = args[1:]
+			ctx := context.Background()
+			return runtime.Exec(ctx, dockerExecOptions)
+		},
+	}
+)
+
+func init() {
+	DockerExecCMD.PersistentFlags().BoolVarP(&dockerExecOptions.Sidecar, "sidecar", "s", false, "Run the command in the sidecar container instead of the app container, which needs a command as the sidecar image has no shell")
+	DockerExecCMD.PersistentFlags().BoolVarP(&dockerExecOptions.Tty, "tty", "t", true, "Allocate a TTY when stdin is a terminal")
+	DockerCMD.AddCommand(DockerExecCMD)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
 	"github.com/yamajik/kess/runtimes"
 )
 
@@ -21,7 +22,11 @@ var (
 )
 
 func init() {
-	DockerInstallCMD.PersistentFlags().StringVarP(&dockerInstallOptions.RuntimeVersion, "runtime-version", "", "latest", "The version of the Dapr runtime to install, for example: 1.0.0")
-	DockerInstallCMD.PersistentFlags().StringVarP(&dockerInstallOptions.DashboardVersion, "dashboard-version", "", "latest", "The version of the Dapr dashboard to install, for example: 1.0.0")
+	DockerInstallCMD.PersistentFlags().StringVarP(&dockerInstallOptions.RuntimeVersion, "runtime-version", "", dapr.DefaultRuntimeVersion, "The version of the Dapr runtime to install, for example: 1.0.0")
+	DockerInstallCMD.PersistentFlags().StringVarP(&dockerInstallOptions.DashboardVersion, "dashboard-version", "", dapr.DefaultDashboardVersion, "The version of the Dapr dashboard to install, for example: 1.0.0")
+	DockerInstallCMD.PersistentFlags().StringVarP(&dockerInstallOptions.Bundle, "bundle", "", "", "The bundle file created by kess docker bundle save to install from without network")
+	DockerInstallCMD.PersistentFlags().StringVarP(&dockerInstallOptions.Tracing, "tracing", "", runtimes.DefaultDockerRuntimeTracing, "The tracing system to install. Valid values are: zipkin, otel, none")
+	DockerInstallCMD.PersistentFlags().StringVarP(&dockerInstallOptions.TracingExporter, "otel-exporter", "", runtimes.DefaultDockerRuntimeOtelExporter, "The exporter of the otel collector. Valid values are: console, file")
+	DockerInstallCMD.PersistentFlags().BoolVarP(&dockerInstallOptions.Metrics, "metrics", "", false, "Install Prometheus to scrape the metrics of the sidecars")
 	DockerCMD.AddCommand(DockerInstallCMD)
 }
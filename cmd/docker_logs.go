@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
 	"github.com/yamajik/kess/runtimes"
 )
 
@@ -24,5 +25,14 @@ var (
 func init() {
 	DockerLogsCMD.PersistentFlags().BoolVarP(&dockerLogsOptions.Follow, "follow", "f", false, "Follow logs")
 	DockerLogsCMD.PersistentFlags().StringVarP(&dockerLogsOptions.Tail, "tail", "", "", "Tail logs")
+	DockerLogsCMD.PersistentFlags().StringVarP(&dockerLogsOptions.Since, "since", "", "", "Show logs since timestamp (e.g. 2021-01-02T13:23:37) or relative (e.g. 42m for 42 minutes)")
+	DockerLogsCMD.PersistentFlags().StringVarP(&dockerLogsOptions.Until, "until", "", "", "Show logs before a timestamp (e.g. 2021-01-02T13:23:37) or relative (e.g. 42m for 42 minutes)")
+	DockerLogsCMD.PersistentFlags().BoolVarP(&dockerLogsOptions.Timestamps, "timestamps", "t", false, "Show timestamps")
+	DockerLogsCMD.PersistentFlags().BoolVarP(&dockerLogsOptions.Sidecar, "sidecar", "s", false, "Show sidecar logs instead of app logs")
+	DockerLogsCMD.PersistentFlags().BoolVarP(&dockerLogsOptions.All, "all", "a", false, "Show app and sidecar logs together")
+	DockerLogsCMD.PersistentFlags().StringVarP(&dockerLogsOptions.Pipeline.Level, "filter-level", "", "", "Only show daprd records at or above this level. Valid values are: debug, info, warn, error, fatal, or panic")
+	DockerLogsCMD.PersistentFlags().StringVarP(&dockerLogsOptions.Pipeline.Scope, "filter-scope", "", "", "Only show daprd records whose scope starts with this value, for example: dapr.runtime")
+	DockerLogsCMD.PersistentFlags().StringVarP(&dockerLogsOptions.Pipeline.AppID, "filter-app-id", "", "", "Only show daprd records of this app id")
+	DockerLogsCMD.PersistentFlags().StringVarP(&dockerLogsOptions.Pipeline.Output, "output", "o", dapr.LogOutputText, "The output format. Valid values are: text, json")
 	DockerCMD.AddCommand(DockerLogsCMD)
 }
@@ -26,6 +26,7 @@ func init() {
 	DockerRunCMD.PersistentFlags().StringVarP(&dockerRunOptions.AppID, "app-id", "a", "", "The id for your application, used for service discovery")
 	DockerRunCMD.MarkPersistentFlagRequired("app-id")
 	DockerRunCMD.PersistentFlags().StringVarP(&dockerRunOptions.AppImage, "app-image", "i", "", "The image your application used")
+	DockerRunCMD.PersistentFlags().BoolVarP(&dockerRunOptions.Hybrid, "hybrid", "", false, "Run your app as a host process with its Dapr sidecar in a container on the host network, fails on macOS and Windows where Docker Desktop has no host network, ignored with --app-image")
 	DockerRunCMD.PersistentFlags().IntVarP(&dockerRunOptions.AppPort, "app-port", "p", dapr.DefaultRandomPort, "The port your application is listening on")
 	DockerRunCMD.PersistentFlags().StringVarP(&dockerRunOptions.ConfigFile, "config", "c", dapr.DefaultConfigFilePath(), "Dapr configuration file")
 	DockerRunCMD.PersistentFlags().IntVarP(&dockerRunOptions.HTTPPort, "dapr-http-port", "H", dapr.DefaultRandomPort, "The http port for Dapr to listen on")
@@ -41,5 +42,20 @@ func init() {
 	DockerRunCMD.PersistentFlags().IntVarP(&dockerRunOptions.MetricsPort, "metrics-port", "M", dapr.DefaultRandomPort, "The port of metrics on dapr")
 	DockerRunCMD.PersistentFlags().StringVarP(&dockerRunOptions.AppPwd, "pwd", "", "", "The dir to run cmd in")
 	DockerRunCMD.PersistentFlags().IntVarP(&dockerRunOptions.AppWaitTimeoutInSeconds, "wait-timeout", "", dapr.DefaultAppWaitTimeoutInSeconds, "The timeout in second to wait for app start")
+	DockerRunCMD.PersistentFlags().IntVarP(&dockerRunOptions.LogMaxSizeInMB, "log-max-size", "", dapr.DefaultLogMaxSizeInMB, "The size in MB at which app and Dapr log files are rotated")
+	DockerRunCMD.PersistentFlags().IntVarP(&dockerRunOptions.LogMaxFiles, "log-max-files", "", dapr.DefaultLogMaxFiles, "The number of rotated app and Dapr log files to keep")
+	DockerRunCMD.PersistentFlags().StringVarP(&dockerRunOptions.Restart.Name, "restart", "", dapr.RestartNo, "The restart policy of your app. Valid values are: no, on-failure, always")
+	DockerRunCMD.PersistentFlags().IntVarP(&dockerRunOptions.Restart.MaxRetries, "restart-max-retries", "", 0, "The number of restarts in a row before giving up, 0 for unlimited")
+	DockerRunCMD.PersistentFlags().BoolVarP(&dockerRunOptions.Restart.Dapr, "restart-dapr", "", false, "Apply the restart policy to Dapr as well")
+	DockerRunCMD.PersistentFlags().BoolVarP(&dockerRunOptions.Detach, "detach", "", false, "Run Dapr and your app in the background, stop them with kess stop")
+	DockerRunCMD.PersistentFlags().IntVarP(&dockerRunOptions.ShutdownGracePeriodInSeconds, "shutdown-grace-period", "", dapr.DefaultShutdownGracePeriodInSeconds, "The time in second to wait for app and Dapr to exit before killing them")
+	DockerRunCMD.PersistentFlags().BoolVarP(&dockerRunOptions.Debug.Enabled, "debug-app", "", false, "Run your app under a headless Delve server for debuggers to attach to")
+	DockerRunCMD.PersistentFlags().IntVarP(&dockerRunOptions.Debug.Port, "debug-port", "", 0, "The port of the Delve server, published from the app container with --app-image, implies --debug-app")
+	DockerRunCMD.PersistentFlags().BoolVarP(&dockerRunOptions.Debug.Wait, "debug-wait", "", false, "Wait for a debugger to attach before starting your app")
+	DockerRunCMD.PersistentFlags().StringVarP(&dockerRunOptions.Debug.Delve, "delve", "", dapr.DefaultDebugDelve, "The Delve binary, which has to be in the app image with --app-image")
+	DockerRunCMD.PersistentFlags().StringVarP(&dockerRunOptions.Logs.Level, "filter-level", "", "", "Only show daprd records at or above this level. Valid values are: debug, info, warn, error, fatal, or panic")
+	DockerRunCMD.PersistentFlags().StringVarP(&dockerRunOptions.Logs.Scope, "filter-scope", "", "", "Only show daprd records whose scope starts with this value, for example: dapr.runtime")
+	DockerRunCMD.PersistentFlags().StringVarP(&dockerRunOptions.Logs.AppID, "filter-app-id", "", "", "Only show daprd records of this app id")
+	DockerRunCMD.PersistentFlags().StringVarP(&dockerRunOptions.Logs.Output, "output", "o", dapr.LogOutputText, "The log output format. Valid values are: text, json")
 	DockerCMD.AddCommand(DockerRunCMD)
 }
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/runtimes"
+)
+
+var (
+	dockerSecretsSetOptions runtimes.RuntimeSecretsSetOptions
+
+	DockerSecretsSetCMD = &cobra.Command{
+		Use:  "set <key> <value>",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dockerSecretsSetOptions.Key = args[0]
+			dockerSecretsSetOptions.Value = args[1]
+			ctx := context.Background()
+			return runtime.SecretsSet(ctx, dockerSecretsSetOptions)
+		},
+	}
+)
+
+func init() {
+	DockerSecretsCMD.AddCommand(DockerSecretsSetCMD)
+}
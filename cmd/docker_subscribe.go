@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+	"github.com/yamajik/kess/runtimes"
+)
+
+var (
+	dockerSubscribeOptions runtimes.RuntimeSubscribeOptions
+
+	DockerSubscribeCMD = &cobra.Command{
+		Use: "subscribe",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			return runtime.Subscribe(ctx, dockerSubscribeOptions)
+		},
+	}
+)
+
+func init() {
+	DockerSubscribeCMD.PersistentFlags().StringVarP(&dockerSubscribeOptions.PubsubName, "pubsub", "", dapr.DefaultPubsubName, "The name of the pub/sub component")
+	DockerSubscribeCMD.PersistentFlags().StringVarP(&dockerSubscribeOptions.Topic, "topic", "t", "", "The topic to subscribe to")
+	DockerSubscribeCMD.MarkPersistentFlagRequired("topic")
+	DockerSubscribeCMD.PersistentFlags().StringVarP(&dockerSubscribeOptions.AppID, "app-id", "a", "", "The id of the temporary subscriber app, otherwise generated")
+	DockerSubscribeCMD.PersistentFlags().StringVarP(&dockerSubscribeOptions.Output, "output", "o", dapr.LogOutputText, "The output format. Valid values are: text, json")
+	DockerCMD.AddCommand(DockerSubscribeCMD)
+}
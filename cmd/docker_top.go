@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/runtimes"
+)
+
+var (
+	dockerTopOptions runtimes.RuntimeTopOptions
+
+	DockerTopCMD = &cobra.Command{
+		Use: "top",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			return runtime.Top(ctx, dockerTopOptions)
+		},
+	}
+)
+
+func init() {
+	DockerTopCMD.PersistentFlags().DurationVarP(&dockerTopOptions.Interval, "interval", "n", runtimes.DefaultDockerRuntimeTopInterval, "The interval to refresh the containers")
+	DockerTopCMD.PersistentFlags().IntVarP(&dockerTopOptions.LogLines, "log-lines", "", runtimes.DefaultDockerRuntimeTopLogLines, "The number of recent log lines of the selected container to show")
+	DockerCMD.AddCommand(DockerTopCMD)
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/runtimes"
+)
+
+var (
+	dockerUIOptions runtimes.RuntimeUIOptions
+
+	DockerUICMD = &cobra.Command{
+		Use: "ui",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			return runtime.UI(ctx, dockerUIOptions)
+		},
+	}
+)
+
+func init() {
+	DockerUICMD.PersistentFlags().IntVarP(&dockerUIOptions.Port, "port", "p", runtimes.DefaultDockerRuntimeUIPort, "The local port on which to serve the kess UI")
+	DockerUICMD.PersistentFlags().BoolVarP(&dockerUIOptions.Open, "open", "", true, "Open the kess UI in the browser")
+	DockerCMD.AddCommand(DockerUICMD)
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+	"github.com/yamajik/kess/runtimes"
+)
+
+var (
+	dockerUpgradeOptions runtimes.RuntimeUpgradeOptions
+
+	DockerUpgradeCMD = &cobra.Command{
+		Use: "upgrade",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			return runtime.Upgrade(ctx, dockerUpgradeOptions)
+		},
+	}
+)
+
+func init() {
+	DockerUpgradeCMD.PersistentFlags().StringVarP(&dockerUpgradeOptions.RuntimeVersion, "runtime-version", "", dapr.DefaultRuntimeVersion, "The version of the Dapr runtime to upgrade to, for example: 1.0.0")
+	DockerUpgradeCMD.PersistentFlags().StringVarP(&dockerUpgradeOptions.DashboardVersion, "dashboard-version", "", dapr.DefaultDashboardVersion, "The version of the Dapr dashboard to upgrade to, for example: 1.0.0")
+	DockerCMD.AddCommand(DockerUpgradeCMD)
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	invokeConfig dapr.InvokeConfig
+
+	InvokeCMD = &cobra.Command{
+		Use:  "invoke <AppID> <method>",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			invokeConfig.AppID = args[0]
+			invokeConfig.Method = args[1]
+			ctx := context.Background()
+			return dapr.Invoke(ctx, &invokeConfig)
+		},
+	}
+)
+
+func init() {
+	InvokeCMD.PersistentFlags().StringVarP(&invokeConfig.Verb, "verb", "v", http.MethodPost, "The HTTP verb to use")
+	InvokeCMD.PersistentFlags().StringVarP(&invokeConfig.Data, "data", "d", "", "The data to send")
+	InvokeCMD.PersistentFlags().StringVarP(&invokeConfig.DataFile, "data-file", "f", "", "The file to read the data to send from")
+	InvokeCMD.PersistentFlags().StringArrayVarP(&invokeConfig.Headers, "header", "", []string{}, "A header to send as key:value, can be given multiple times")
+	addClientFlags(InvokeCMD, &invokeConfig.ClientConfig)
+	RootCMD.AddCommand(InvokeCMD)
+}
+
+func addClientFlags(cmd *cobra.Command, config *dapr.ClientConfig) {
+	cmd.PersistentFlags().StringVarP(&config.Address, "dapr-address", "", dapr.DefaultIngressHTTPAddress, "The HTTP address of the Dapr sidecar to call through")
+	cmd.PersistentFlags().StringVarP(&config.Via, "via", "", "", "Call through the sidecar of this local app instead of the ingress sidecar")
+	cmd.PersistentFlags().StringVarP(&config.Output, "output", "o", dapr.LogOutputText, "The output format. Valid values are: text, json")
+}
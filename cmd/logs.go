@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	logsConfig dapr.StandaloneLogsConfig
+
+	LogsCMD = &cobra.Command{
+		Use:  "logs",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logsConfig.AppID = args[0]
+			ctx := context.Background()
+			return dapr.StandaloneLogs(ctx, &logsConfig)
+		},
+	}
+)
+
+func init() {
+	LogsCMD.PersistentFlags().BoolVarP(&logsConfig.Follow, "follow", "f", false, "Follow logs")
+	LogsCMD.PersistentFlags().IntVarP(&logsConfig.Tail, "tail", "", -1, "Number of lines to show from the end of the logs, -1 for all")
+	LogsCMD.PersistentFlags().BoolVarP(&logsConfig.Sidecar, "sidecar", "s", false, "Show sidecar logs instead of app logs")
+	LogsCMD.PersistentFlags().BoolVarP(&logsConfig.All, "all", "a", false, "Show app and sidecar logs together")
+	LogsCMD.PersistentFlags().StringVarP(&logsConfig.Logs.Level, "filter-level", "", "", "Only show daprd records at or above this level. Valid values are: debug, info, warn, error, fatal, or panic")
+	LogsCMD.PersistentFlags().StringVarP(&logsConfig.Logs.Scope, "filter-scope", "", "", "Only show daprd records whose scope starts with this value, for example: dapr.runtime")
+	LogsCMD.PersistentFlags().StringVarP(&logsConfig.Logs.AppID, "filter-app-id", "", "", "Only show daprd records of this app id")
+	LogsCMD.PersistentFlags().StringVarP(&logsConfig.Logs.Output, "output", "o", dapr.LogOutputText, "The output format. Valid values are: text, json")
+	RootCMD.AddCommand(LogsCMD)
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	metricsConfig dapr.MetricsConfig
+
+	MetricsCMD = &cobra.Command{
+		Use:  "metrics <AppID>",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			return dapr.Metrics(ctx, &metricsConfig, args[0])
+		},
+	}
+)
+
+func init() {
+	MetricsCMD.PersistentFlags().StringVarP(&metricsConfig.PrometheusAddress, "prometheus-address", "", dapr.DefaultPrometheusAddress, "The address of Prometheus")
+	MetricsCMD.PersistentFlags().StringVarP(&metricsConfig.Output, "output", "o", dapr.LogOutputText, "The output format. Valid values are: text, json")
+	RootCMD.AddCommand(MetricsCMD)
+}
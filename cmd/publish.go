@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	publishConfig dapr.PublishConfig
+
+	PublishCMD = &cobra.Command{
+		Use: "publish",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			return dapr.Publish(ctx, &publishConfig)
+		},
+	}
+)
+
+func init() {
+	PublishCMD.PersistentFlags().StringVarP(&publishConfig.PubsubName, "pubsub", "", dapr.DefaultPubsubName, "The name of the pub/sub component")
+	PublishCMD.PersistentFlags().StringVarP(&publishConfig.Topic, "topic", "t", "", "The topic to publish to")
+	PublishCMD.MarkPersistentFlagRequired("topic")
+	PublishCMD.PersistentFlags().StringVarP(&publishConfig.Data, "data", "d", "", "The data of the event")
+	PublishCMD.PersistentFlags().StringVarP(&publishConfig.DataFile, "data-file", "f", "", "The file to read the data of the event from")
+	PublishCMD.PersistentFlags().StringArrayVarP(&publishConfig.Metadata, "metadata", "m", []string{}, "A metadata as key=value passed to the pub/sub component, can be given multiple times")
+	addClientFlags(PublishCMD, &publishConfig.ClientConfig)
+	RootCMD.AddCommand(PublishCMD)
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
 	"github.com/yamajik/kess/runtimes"
 )
 
@@ -17,6 +19,8 @@ var (
 		Use:   "kess",
 		Short: "Kess CLI",
 		Long:  "Kess DAR based on Dapr",
+		// Errors returned from commands are runtime failures, not usage mistakes.
+		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
@@ -36,6 +40,10 @@ func Execute(version string) {
 			fmt.Fprintln(os.Stderr, err)
 
 		}
+		var exitErr *dapr.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
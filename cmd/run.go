@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"context"
+
 	"github.com/dapr/cli/pkg/standalone"
 	"github.com/spf13/cobra"
 	"github.com/yamajik/kess/dapr"
@@ -13,8 +15,8 @@ var (
 		Use: "run",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			runConfig.Arguments = args
-			dapr.StandaloneRun(&runConfig)
-			return nil
+			ctx := context.Background()
+			return dapr.StandaloneRun(ctx, &runConfig)
 		},
 	}
 )
@@ -37,5 +39,20 @@ func init() {
 	RunCMD.PersistentFlags().IntVarP(&runConfig.MetricsPort, "metrics-port", "M", dapr.DefaultRandomPort, "The port of metrics on dapr")
 	RunCMD.PersistentFlags().StringVarP(&runConfig.AppPwd, "pwd", "", "", "The dir to run cmd in")
 	RunCMD.PersistentFlags().IntVarP(&runConfig.AppWaitTimeoutInSeconds, "wait-timeout", "", dapr.DefaultAppWaitTimeoutInSeconds, "The timeout in second to wait for app start")
+	RunCMD.PersistentFlags().IntVarP(&runConfig.LogMaxSizeInMB, "log-max-size", "", dapr.DefaultLogMaxSizeInMB, "The size in MB at which app and Dapr log files are rotated")
+	RunCMD.PersistentFlags().IntVarP(&runConfig.LogMaxFiles, "log-max-files", "", dapr.DefaultLogMaxFiles, "The number of rotated app and Dapr log files to keep")
+	RunCMD.PersistentFlags().StringVarP(&runConfig.Restart.Name, "restart", "", dapr.RestartNo, "The restart policy of your app. Valid values are: no, on-failure, always")
+	RunCMD.PersistentFlags().IntVarP(&runConfig.Restart.MaxRetries, "restart-max-retries", "", 0, "The number of restarts in a row before giving up, 0 for unlimited")
+	RunCMD.PersistentFlags().BoolVarP(&runConfig.Restart.Dapr, "restart-dapr", "", false, "Apply the restart policy to Dapr as well")
+	RunCMD.PersistentFlags().BoolVarP(&runConfig.Detach, "detach", "", false, "Run Dapr and your app in the background, stop them with kess stop")
+	RunCMD.PersistentFlags().IntVarP(&runConfig.ShutdownGracePeriodInSeconds, "shutdown-grace-period", "", dapr.DefaultShutdownGracePeriodInSeconds, "The time in second to wait for app and Dapr to exit before killing them")
+	RunCMD.PersistentFlags().BoolVarP(&runConfig.Debug.Enabled, "debug-app", "", false, "Run your app under a headless Delve server for debuggers to attach to")
+	RunCMD.PersistentFlags().IntVarP(&runConfig.Debug.Port, "debug-port", "", 0, "The port of the Delve server, implies --debug-app")
+	RunCMD.PersistentFlags().BoolVarP(&runConfig.Debug.Wait, "debug-wait", "", false, "Wait for a debugger to attach before starting your app")
+	RunCMD.PersistentFlags().StringVarP(&runConfig.Debug.Delve, "delve", "", dapr.DefaultDebugDelve, "The Delve binary")
+	RunCMD.PersistentFlags().StringVarP(&runConfig.Logs.Level, "filter-level", "", "", "Only show daprd records at or above this level. Valid values are: debug, info, warn, error, fatal, or panic")
+	RunCMD.PersistentFlags().StringVarP(&runConfig.Logs.Scope, "filter-scope", "", "", "Only show daprd records whose scope starts with this value, for example: dapr.runtime")
+	RunCMD.PersistentFlags().StringVarP(&runConfig.Logs.AppID, "filter-app-id", "", "", "Only show daprd records of this app id")
+	RunCMD.PersistentFlags().StringVarP(&runConfig.Logs.Output, "output", "o", dapr.LogOutputText, "The log output format. Valid values are: text, json")
 	RootCMD.AddCommand(RunCMD)
 }
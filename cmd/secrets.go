@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	secretsConfig dapr.SecretsConfig
+
+	SecretsCMD = &cobra.Command{
+		Use: "secrets",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+)
+
+func init() {
+	addClientFlags(SecretsCMD, &secretsConfig.ClientConfig)
+	RootCMD.AddCommand(SecretsCMD)
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	SecretsGetCMD = &cobra.Command{
+		Use:  "get <store> [key]",
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			secretsConfig.StoreName = args[0]
+			key := ""
+			if len(args) > 1 {
+				key = args[1]
+			}
+			ctx := context.Background()
+			return dapr.SecretsGet(ctx, &secretsConfig, key)
+		},
+	}
+)
+
+func init() {
+	SecretsCMD.AddCommand(SecretsGetCMD)
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	stateConfig dapr.StateConfig
+
+	StateCMD = &cobra.Command{
+		Use: "state",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+)
+
+func init() {
+	StateCMD.PersistentFlags().StringVarP(&stateConfig.StoreName, "store", "", dapr.DefaultStateStoreName, "The name of the state store component")
+	StateCMD.PersistentFlags().StringVarP(&stateConfig.AppID, "app-id", "a", "", "Scope keys by the id of the app which saved them, through the sidecar of that local app, or in raw mode for apps in containers")
+	StateCMD.PersistentFlags().BoolVarP(&stateConfig.Raw, "raw", "r", false, "Read keys from Redis directly with get, keys are glob patterns, e.g. to inspect actor state")
+	StateCMD.PersistentFlags().StringVarP(&stateConfig.RedisAddress, "redis-address", "", dapr.DefaultRedisAddress, "The address of Redis in raw mode")
+	StateCMD.PersistentFlags().StringVarP(&stateConfig.RedisPassword, "redis-password", "", "", "The password of Redis in raw mode")
+	addClientFlags(StateCMD, &stateConfig.ClientConfig)
+	RootCMD.AddCommand(StateCMD)
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	stateDeleteETag string
+
+	StateDeleteCMD = &cobra.Command{
+		Use:  "delete <key>",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			return dapr.StateDelete(ctx, &stateConfig, args[0], stateDeleteETag)
+		},
+	}
+)
+
+func init() {
+	StateDeleteCMD.PersistentFlags().StringVarP(&stateDeleteETag, "etag", "e", "", "Only delete if the state still has this ETag")
+	StateCMD.AddCommand(StateDeleteCMD)
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	StateGetCMD = &cobra.Command{
+		Use:  "get <key>...",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			return dapr.StateGet(ctx, &stateConfig, args)
+		},
+	}
+)
+
+func init() {
+	StateCMD.AddCommand(StateGetCMD)
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	stateQueryData     string
+	stateQueryDataFile string
+
+	StateQueryCMD = &cobra.Command{
+		Use: "query",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query, err := dapr.ReadData(stateQueryData, stateQueryDataFile)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			return dapr.StateQuery(ctx, &stateConfig, query)
+		},
+	}
+)
+
+func init() {
+	StateQueryCMD.PersistentFlags().StringVarP(&stateQueryData, "data", "d", "", "The query as JSON")
+	StateQueryCMD.PersistentFlags().StringVarP(&stateQueryDataFile, "data-file", "f", "", "The file to read the query from")
+	StateCMD.AddCommand(StateQueryCMD)
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	stateSetETag string
+
+	StateSetCMD = &cobra.Command{
+		Use:  "set <key> <value>",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			return dapr.StateSet(ctx, &stateConfig, args[0], args[1], stateSetETag)
+		},
+	}
+)
+
+func init() {
+	StateSetCMD.PersistentFlags().StringVarP(&stateSetETag, "etag", "e", "", "Only save if the state still has this ETag")
+	StateCMD.AddCommand(StateSetCMD)
+}
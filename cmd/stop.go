@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	stopConfig dapr.StandaloneStopConfig
+
+	StopCMD = &cobra.Command{
+		Use:  "stop",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			for _, appid := range args {
+				stopConfig.AppID = appid
+				if err := dapr.StandaloneStop(ctx, &stopConfig); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+)
+
+func init() {
+	StopCMD.PersistentFlags().IntVarP(&stopConfig.ShutdownGracePeriodInSeconds, "shutdown-grace-period", "", dapr.DefaultShutdownGracePeriodInSeconds, "The time in second to wait for app and Dapr to exit before killing them")
+	RootCMD.AddCommand(StopCMD)
+}
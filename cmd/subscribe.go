@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	subscribeConfig dapr.SubscribeConfig
+
+	SubscribeCMD = &cobra.Command{
+		Use: "subscribe",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			return dapr.Subscribe(ctx, &subscribeConfig)
+		},
+	}
+)
+
+func init() {
+	SubscribeCMD.PersistentFlags().StringVarP(&subscribeConfig.PubsubName, "pubsub", "", dapr.DefaultPubsubName, "The name of the pub/sub component")
+	SubscribeCMD.PersistentFlags().StringVarP(&subscribeConfig.Topic, "topic", "t", "", "The topic to subscribe to")
+	SubscribeCMD.MarkPersistentFlagRequired("topic")
+	SubscribeCMD.PersistentFlags().StringVarP(&subscribeConfig.AppID, "app-id", "a", "", "The id of the temporary subscriber app, otherwise generated")
+	SubscribeCMD.PersistentFlags().StringVarP(&subscribeConfig.Output, "output", "o", dapr.LogOutputText, "The output format. Valid values are: text, json")
+	RootCMD.AddCommand(SubscribeCMD)
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	tracesConfig dapr.TracesConfig
+
+	TracesCMD = &cobra.Command{
+		Use: "traces",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+)
+
+func init() {
+	TracesCMD.PersistentFlags().StringVarP(&tracesConfig.ZipkinAddress, "zipkin-address", "", dapr.DefaultZipkinAddress, "The address of Zipkin")
+	TracesCMD.PersistentFlags().StringVarP(&tracesConfig.Output, "output", "o", dapr.LogOutputText, "The output format. Valid values are: text, json")
+	RootCMD.AddCommand(TracesCMD)
+}
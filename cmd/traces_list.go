@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	tracesListConfig dapr.TracesListConfig
+
+	TracesListCMD = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tracesListConfig.TracesConfig = tracesConfig
+			ctx := context.Background()
+			return dapr.TracesList(ctx, &tracesListConfig)
+		},
+	}
+)
+
+func init() {
+	TracesListCMD.PersistentFlags().StringVarP(&tracesListConfig.Service, "service", "s", "", "Only list traces with spans of this service, the app id")
+	TracesListCMD.PersistentFlags().DurationVarP(&tracesListConfig.Since, "since", "", dapr.DefaultTracesSince, "List traces newer than this relative duration, e.g. 5m")
+	TracesListCMD.PersistentFlags().IntVarP(&tracesListConfig.Limit, "limit", "n", dapr.DefaultTracesLimit, "The maximum number of traces to list")
+	TracesCMD.AddCommand(TracesListCMD)
+}
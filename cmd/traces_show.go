@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	TracesShowCMD = &cobra.Command{
+		Use:  "show <traceID>",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			return dapr.TracesShow(ctx, &tracesConfig, args[0])
+		},
+	}
+)
+
+func init() {
+	TracesCMD.AddCommand(TracesShowCMD)
+}
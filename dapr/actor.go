@@ -0,0 +1,210 @@
+package dapr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	DefaultActorRemindersKey = "actors||{ActorType}"
+)
+
+type ActorConfig struct {
+	ClientConfig
+	RedisAddress     string
+	RedisPassword    string
+	PlacementAddress string
+}
+
+func (c *ActorConfig) Default() error {
+	if c.RedisAddress == "" {
+		c.RedisAddress = DefaultRedisAddress
+	}
+	if c.PlacementAddress == "" {
+		c.PlacementAddress = DefaultPlacementHealthAddress
+	}
+	return nil
+}
+
+type ActorInvokeConfig struct {
+	ActorConfig
+	ActorType string
+	ActorID   string
+	Method    string
+	Data      string
+	DataFile  string
+}
+
+func ActorInvoke(ctx context.Context, config *ActorInvokeConfig) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(&config.ClientConfig)
+	if err != nil {
+		return err
+	}
+
+	body, err := ReadData(config.Data, config.DataFile)
+	if err != nil {
+		return err
+	}
+	headers := http.Header{}
+	if body != nil {
+		headers.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(ctx, ClientRequest{
+		Method:  http.MethodPost,
+		Path:    fmt.Sprintf("/v1.0/actors/%s/%s/method/%s", config.ActorType, config.ActorID, config.Method),
+		Headers: headers,
+		Body:    body,
+	})
+	if err != nil {
+		return err
+	}
+	if err := resp.Print(config.Output); err != nil {
+		return err
+	}
+	return resp.Err()
+}
+
+// ActorState reads a single key through the sidecar actor API, or all keys of
+// the actor from Redis, since Dapr has no API to list them.
+func ActorState(ctx context.Context, config *ActorConfig, actorType string, actorID string, key string) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+
+	if key != "" {
+		client, err := NewClient(&config.ClientConfig)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(ctx, ClientRequest{
+			Method: http.MethodGet,
+			Path:   fmt.Sprintf("/v1.0/actors/%s/%s/state/%s", actorType, actorID, key),
+		})
+		if err != nil {
+			return err
+		}
+		if err := resp.Err(); err != nil {
+			return err
+		}
+		return printStateItems([]StateItem{{Key: key, Value: stateValue(resp.Body)}}, config.Output)
+	}
+
+	// Actor state keys are <AppID>||<ActorType>||<ActorID>||<Key>.
+	stateConfig := &StateConfig{
+		ClientConfig:  config.ClientConfig,
+		Raw:           true,
+		RedisAddress:  config.RedisAddress,
+		RedisPassword: config.RedisPassword,
+	}
+	return stateRawGet(stateConfig, []string{strings.Join([]string{"*", actorType, actorID, "*"}, DefaultStateKeySeparator)})
+}
+
+type ActorReminder struct {
+	ActorID        string          `json:"actorID"`
+	ActorType      string          `json:"actorType"`
+	Name           string          `json:"name"`
+	Data           json.RawMessage `json:"data,omitempty"`
+	Period         string          `json:"period"`
+	DueTime        string          `json:"dueTime"`
+	RegisteredTime string          `json:"registeredTime,omitempty"`
+}
+
+// ActorReminders reads the reminders the sidecars persist per actor type in
+// the actor state store.
+func ActorReminders(ctx context.Context, config *ActorConfig, actorType string, actorID string) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+
+	conn, err := dialRedis(config.RedisAddress, config.RedisPassword)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	fields, err := conn.hgetall(strings.ReplaceAll(DefaultActorRemindersKey, "{ActorType}", actorType))
+	if err != nil {
+		return err
+	}
+
+	reminders := []ActorReminder{}
+	if data := fields["data"]; data != "" {
+		all := []ActorReminder{}
+		if err := json.Unmarshal([]byte(data), &all); err != nil {
+			return errors.WithStack(err)
+		}
+		for _, reminder := range all {
+			if actorID == "" || reminder.ActorID == actorID {
+				reminders = append(reminders, reminder)
+			}
+		}
+	}
+
+	if config.Output == LogOutputJSON {
+		return printJSON(reminders)
+	}
+	for _, reminder := range reminders {
+		fmt.Fprintf(os.Stdout, "%s\t%s\t%s\tdue=%s\tperiod=%s\t%s\n", reminder.ActorType, reminder.ActorID, reminder.Name, reminder.DueTime, reminder.Period, string(reminder.Data))
+	}
+	return nil
+}
+
+// ActorTimers explains why timers can not be listed: they only live in the
+// memory of the sidecar hosting the actor, neither the state store nor any
+// Dapr API has them.
+func ActorTimers(ctx context.Context, config *ActorConfig, actorType string, actorID string) error {
+	return errors.Errorf("Timers of actor type %s only live in the memory of the sidecar hosting the actor and can not be listed. Register reminders to have them persisted, then list them with: kess actor reminders list %s", actorType, actorType)
+}
+
+type PlacementTable struct {
+	TableVersion int                  `json:"tableVersion"`
+	HostList     []PlacementTableHost `json:"hostList"`
+}
+
+type PlacementTableHost struct {
+	Name       string   `json:"name"`
+	AppID      string   `json:"appId"`
+	ActorTypes []string `json:"actorTypes"`
+	UpdatedAt  int64    `json:"updatedAt"`
+}
+
+// ActorPlacement reads the placement table from the health server of the
+// placement service, which runtimes since 1.8 serve at /placement/state.
+func ActorPlacement(ctx context.Context, config *ActorConfig) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(&ClientConfig{Address: config.PlacementAddress, Output: config.Output})
+	if err != nil {
+		return err
+	}
+
+	table := PlacementTable{}
+	if _, err := client.DoJSON(ctx, ClientRequest{
+		Method: http.MethodGet,
+		Path:   "/placement/state",
+	}, nil, &table); err != nil {
+		return errors.Wrap(err, "Error reading the placement table")
+	}
+
+	if config.Output == LogOutputJSON {
+		return printJSON(table)
+	}
+	fmt.Fprintf(os.Stdout, "Table version %d\n", table.TableVersion)
+	for _, host := range table.HostList {
+		fmt.Fprintf(os.Stdout, "%s\t%s\t%s\n", host.AppID, host.Name, strings.Join(host.ActorTypes, ","))
+	}
+	return nil
+}
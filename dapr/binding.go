@@ -0,0 +1,220 @@
+package dapr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	DefaultBindingListenAppID = "kess-binding-{Suffix}"
+)
+
+type BindingInvokeConfig struct {
+	ClientConfig
+	Name      string
+	Operation string
+	Data      string
+	DataFile  string
+	Metadata  []string
+	// HTTPURL invokes a new HTTP binding to this URL through a temporary
+	// sidecar, instead of an installed binding.
+	HTTPURL string
+}
+
+func BindingInvoke(ctx context.Context, config *BindingInvokeConfig) error {
+	if config.HTTPURL != "" {
+		return bindingInvokeHTTP(ctx, config)
+	}
+
+	client, err := NewClient(&config.ClientConfig)
+	if err != nil {
+		return err
+	}
+
+	data, err := ReadData(config.Data, config.DataFile)
+	if err != nil {
+		return err
+	}
+
+	metadata := map[string]string{}
+	for _, m := range config.Metadata {
+		kv := strings.SplitN(m, "=", 2)
+		if len(kv) != 2 {
+			return errors.Errorf("Invalid metadata, expected key=value: %s", m)
+		}
+		metadata[kv[0]] = kv[1]
+	}
+
+	request := map[string]interface{}{
+		"operation": config.Operation,
+		"metadata":  metadata,
+	}
+	if data != nil {
+		request["data"] = stateValue(data)
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	resp, err := client.Do(ctx, ClientRequest{
+		Method:  http.MethodPost,
+		Path:    fmt.Sprintf("/v1.0/bindings/%s", config.Name),
+		Headers: http.Header{"Content-Type": []string{"application/json"}},
+		Body:    body,
+	})
+	if err != nil {
+		return err
+	}
+	if err := resp.Print(config.Output); err != nil {
+		return err
+	}
+	return resp.Err()
+}
+
+// bindingInvokeHTTP runs a temporary app whose sidecar only loads an HTTP
+// binding of the name, invokes it through that sidecar and stops.
+func bindingInvokeHTTP(ctx context.Context, config *BindingInvokeConfig) error {
+	if config.Via != "" {
+		return errors.New("An HTTP binding is invoked through its own temporary sidecar, not through another app")
+	}
+
+	dir, err := tempComponentsDir(CreateHTTPBindingComponent(config.Name, HTTPBindingComponentOptions{URL: config.HTTPURL}))
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var invokeErr error
+	invoked := false
+	print.InfoStatusEvent(os.Stdout, "Invoking HTTP binding %s to %s", config.Name, config.HTTPURL)
+	err = runTempApp(runCtx, nil, tempAppID(DefaultBindingListenAppID), dir, http.NotFoundHandler(), func(state *AppState) error {
+		defer cancel()
+		invoked = true
+		invoke := *config
+		invoke.HTTPURL = ""
+		invoke.Address = fmt.Sprintf("localhost:%d", state.DaprHTTPPort)
+		invokeErr = BindingInvoke(ctx, &invoke)
+		return nil
+	})
+	if invokeErr != nil {
+		return invokeErr
+	}
+	if err != nil {
+		return err
+	}
+	if !invoked {
+		return errors.New("The temporary sidecar stopped before the binding was invoked")
+	}
+	return nil
+}
+
+// tempComponentsDir writes the component alone into a new directory, for a
+// sidecar to load instead of the installed components.
+func tempComponentsDir(component Component) (string, error) {
+	dir, err := ioutil.TempDir("", "kess-components-")
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	buf, err := yaml.Marshal(component)
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", errors.WithStack(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, fmt.Sprintf("%s.yaml", component.Metadata.Name)), buf, 0644); err != nil {
+		os.RemoveAll(dir)
+		return "", errors.WithStack(err)
+	}
+	return dir, nil
+}
+
+type BindingListenConfig struct {
+	AppID  string
+	Name   string
+	Cron   string
+	Output string
+	Runner TempAppRunner
+}
+
+func (c *BindingListenConfig) Default() error {
+	if c.AppID == "" {
+		c.AppID = tempAppID(DefaultBindingListenAppID)
+	}
+	if c.Output == "" {
+		c.Output = LogOutputText
+	}
+	if c.Output != LogOutputText && c.Output != LogOutputJSON {
+		return errors.Errorf("Unknown output: %s", c.Output)
+	}
+	return nil
+}
+
+// BindingListen runs a temporary app receiving the events of an input
+// binding. With a cron schedule, the app only loads a cron binding of that
+// name instead of the installed components, to try bindings end to end. The
+// runner decides where its sidecar runs.
+func BindingListen(ctx context.Context, config *BindingListenConfig) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+
+	componentsPath := ""
+	if config.Cron != "" {
+		dir, err := tempComponentsDir(CreateCronBindingComponent(config.Name, CronBindingComponentOptions{Schedule: config.Cron}))
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+		componentsPath = dir
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/"+config.Name, func(w http.ResponseWriter, r *http.Request) {
+		// The sidecar checks with OPTIONS whether the app listens to the binding.
+		if r.Method == http.MethodOptions {
+			return
+		}
+		buf, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		printBindingEvent(config.Name, r.Header, buf, config.Output)
+	})
+
+	print.InfoStatusEvent(os.Stdout, "Listening to binding %s as app %s", config.Name, config.AppID)
+	return runTempApp(ctx, config.Runner, config.AppID, componentsPath, mux, nil)
+}
+
+func printBindingEvent(name string, headers http.Header, buf []byte, output string) {
+	now := time.Now().Format(time.RFC3339)
+	if output == LogOutputJSON {
+		metadata := map[string]string{}
+		for k := range headers {
+			metadata[k] = headers.Get(k)
+		}
+		line, _ := json.Marshal(map[string]interface{}{
+			"time":     now,
+			"binding":  name,
+			"metadata": metadata,
+			"data":     stateValue(buf),
+		})
+		fmt.Fprintln(os.Stdout, string(line))
+		return
+	}
+	fmt.Fprintf(os.Stdout, "%s %s %s\n", now, print.Blue(name), string(buf))
+}
@@ -0,0 +1,72 @@
+package dapr
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestTempComponentsDir(t *testing.T) {
+	tests := []struct {
+		name      string
+		component Component
+		spec      ComponentSpec
+	}{
+		{
+			name:      "cron",
+			component: CreateCronBindingComponent("tick", CronBindingComponentOptions{Schedule: "@every 5s"}),
+			spec:      ComponentSpec{Type: "bindings.cron", Metadata: []ComponentSpecMetadataItem{{Name: "schedule", Value: "@every 5s"}}},
+		},
+		{
+			name:      "http",
+			component: CreateHTTPBindingComponent("hook", HTTPBindingComponentOptions{URL: "http://localhost:8080/hook"}),
+			spec:      ComponentSpec{Type: "bindings.http", Metadata: []ComponentSpecMetadataItem{{Name: "url", Value: "http://localhost:8080/hook"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := tempComponentsDir(tt.component)
+			if err != nil {
+				t.Fatalf("tempComponentsDir() error = %v", err)
+			}
+			defer os.RemoveAll(dir)
+
+			files, err := ioutil.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(files) != 1 || files[0].Name() != tt.component.Metadata.Name+".yaml" {
+				t.Fatalf("tempComponentsDir() wrote %v, want only %s.yaml", files, tt.component.Metadata.Name)
+			}
+			buf, err := ioutil.ReadFile(filepath.Join(dir, files[0].Name()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			component := Component{}
+			if err := yaml.Unmarshal(buf, &component); err != nil {
+				t.Fatalf("component is not YAML: %s", err)
+			}
+			if !reflect.DeepEqual(component.Spec, tt.spec) {
+				t.Errorf("component spec = %+v, want %+v", component.Spec, tt.spec)
+			}
+		})
+	}
+}
+
+func TestBindingInvokeHTTPVia(t *testing.T) {
+	config := &BindingInvokeConfig{
+		ClientConfig: ClientConfig{Via: "myapp"},
+		Name:         "hook",
+		Operation:    "post",
+		HTTPURL:      "http://localhost:8080/hook",
+	}
+	if err := BindingInvoke(context.Background(), config); err == nil {
+		t.Errorf("BindingInvoke() error = nil with --via and --http-url")
+	}
+}
@@ -0,0 +1,25 @@
+package dapr
+
+import (
+	"os/exec"
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return errors.WithStack(err)
+	}
+	go cmd.Wait()
+	return nil
+}
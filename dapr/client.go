@@ -0,0 +1,199 @@
+package dapr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ClientConfig selects the sidecar the Dapr APIs are called through: the
+// ingress sidecar of the Docker runtime by default, or the sidecar of a
+// local standalone app.
+type ClientConfig struct {
+	Address string
+	Via     string
+	Output  string
+}
+
+func (c *ClientConfig) Default() error {
+	if c.Output == "" {
+		c.Output = LogOutputText
+	}
+	if c.Output != LogOutputText && c.Output != LogOutputJSON {
+		return errors.Errorf("Unknown output: %s", c.Output)
+	}
+	if c.Via != "" {
+		state, err := LoadAppState(c.Via)
+		if err != nil {
+			return err
+		}
+		c.Address = fmt.Sprintf("localhost:%d", state.DaprHTTPPort)
+	}
+	if c.Address == "" {
+		c.Address = DefaultIngressHTTPAddress
+	}
+	return nil
+}
+
+type Client struct {
+	config *ClientConfig
+	client *http.Client
+}
+
+func NewClient(config *ClientConfig) (*Client, error) {
+	if err := config.Default(); err != nil {
+		return nil, err
+	}
+	return &Client{
+		config: config,
+		client: &http.Client{Timeout: DefaultClientTimeoutInSeconds * time.Second},
+	}, nil
+}
+
+type ClientRequest struct {
+	Method  string
+	Path    string
+	Query   url.Values
+	Headers http.Header
+	Body    []byte
+}
+
+type ClientResponse struct {
+	Status     string
+	StatusCode int
+	Headers    http.Header
+	Body       []byte
+}
+
+func (c *Client) Do(ctx context.Context, request ClientRequest) (*ClientResponse, error) {
+	u := url.URL{Scheme: "http", Host: c.config.Address, Path: request.Path, RawQuery: request.Query.Encode()}
+
+	var body io.Reader
+	if request.Body != nil {
+		body = bytes.NewReader(request.Body)
+	}
+	req, err := http.NewRequest(request.Method, u.String(), body)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	req = req.WithContext(ctx)
+	for k, values := range request.Headers {
+		for _, v := range values {
+			req.Header.Add(k, v)
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error calling Dapr at %s", c.config.Address)
+	}
+	defer resp.Body.Close()
+
+	buf, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ClientResponse{
+		Status:     resp.Status,
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Header,
+		Body:       buf,
+	}, nil
+}
+
+// DoJSON sends value as the JSON body and decodes a successful response into
+// result, if given.
+func (c *Client) DoJSON(ctx context.Context, request ClientRequest, value interface{}, result interface{}) (*ClientResponse, error) {
+	if value != nil {
+		buf, err := json.Marshal(value)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		request.Body = buf
+		if request.Headers == nil {
+			request.Headers = http.Header{}
+		}
+		request.Headers.Set("Content-Type", "application/json")
+	}
+	resp, err := c.Do(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return resp, err
+	}
+	if result != nil && len(resp.Body) > 0 {
+		if err := json.Unmarshal(resp.Body, result); err != nil {
+			return resp, errors.WithStack(err)
+		}
+	}
+	return resp, nil
+}
+
+func (r *ClientResponse) Err() error {
+	if r.StatusCode < 400 {
+		return nil
+	}
+	msg := strings.TrimSpace(string(r.Body))
+	if msg == "" {
+		return errors.Errorf("Request failed with status %s", r.Status)
+	}
+	return errors.Errorf("Request failed with status %s: %s", r.Status, msg)
+}
+
+// Print writes the status, headers and body, or all of them as one JSON
+// object with the body inlined if it is JSON itself.
+func (r *ClientResponse) Print(output string) error {
+	if output == LogOutputJSON {
+		var body interface{} = string(r.Body)
+		if json.Valid(r.Body) {
+			body = json.RawMessage(r.Body)
+		}
+		buf, err := json.MarshalIndent(map[string]interface{}{
+			"status":  r.StatusCode,
+			"headers": r.Headers,
+			"body":    body,
+		}, "", "  ")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		fmt.Fprintln(os.Stdout, string(buf))
+		return nil
+	}
+
+	keys := make([]string, 0, len(r.Headers))
+	for k := range r.Headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintln(os.Stdout, r.Status)
+	for _, k := range keys {
+		fmt.Fprintf(os.Stdout, "%s: %s\n", k, strings.Join(r.Headers[k], ", "))
+	}
+	fmt.Fprintln(os.Stdout)
+	if len(r.Body) > 0 {
+		fmt.Fprintln(os.Stdout, string(r.Body))
+	}
+	return nil
+}
+
+func printJSON(value interface{}) error {
+	buf, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	fmt.Fprintln(os.Stdout, string(buf))
+	return nil
+}
@@ -6,16 +6,38 @@ import (
 )
 
 const (
-	DefaultDaprDirname               = ".dapr"
-	DefaultDaprBinDirname            = "bin"
-	DefaultDaprPlacementFilename     = "placement"
-	DefaultDaprDaprdFilename         = "daprd"
-	DefaultDaprDashboardDirname      = "dashboard"
-	DefaultDaprComponentsDirname     = "components"
-	DefaultDaprConfigurationFilename = "config.yaml"
-	DefaultAppWaitTimeoutInSeconds   = 60
-	DefaultRandomPort                = -1
-	DefaultDashboardPort             = 8000
+	DefaultDaprDirname                  = ".dapr"
+	DefaultDaprBinDirname               = "bin"
+	DefaultDaprPlacementFilename        = "placement"
+	DefaultDaprDaprdFilename            = "daprd"
+	DefaultDaprDashboardDirname         = "dashboard"
+	DefaultDaprComponentsDirname        = "components"
+	DefaultDaprConfigurationFilename    = "config.yaml"
+	DefaultKessDirname                  = ".kess"
+	DefaultKessRunDirname               = "run"
+	DefaultAppStateFilename             = "state.json"
+	DefaultAppLogFilename               = "app.log"
+	DefaultDaprLogFilename              = "daprd.log"
+	DefaultKessLogFilename              = "kess.log"
+	DefaultSecretsFilename              = "secrets.json"
+	DefaultAppWaitTimeoutInSeconds      = 60
+	DefaultDebugAppWaitTimeoutInSeconds = 600
+	DefaultDebugPort                    = 2345
+	DefaultShutdownGracePeriodInSeconds = 10
+	DefaultLogMaxSizeInMB               = 10
+	DefaultLogMaxFiles                  = 5
+	DefaultRestartBackoffInSeconds      = 1
+	DefaultRestartMaxBackoffInSeconds   = 30
+	DefaultRandomPort                   = -1
+	DefaultDashboardPort                = 8000
+	DefaultIngressHTTPAddress           = "localhost:50002"
+	DefaultRedisAddress                 = "localhost:50003"
+	DefaultZipkinAddress                = "localhost:50004"
+	DefaultPlacementHealthAddress       = "localhost:50006"
+	DefaultPrometheusAddress            = "localhost:50009"
+	DefaultClientTimeoutInSeconds       = 60
+	DefaultRuntimeVersion               = "latest"
+	DefaultDashboardVersion             = "latest"
 )
 
 func DefaultDaprDirPath() string {
@@ -39,6 +61,23 @@ func DefaultDaprDashboardPath() string {
 	return filepath.Join(DefaultDaprBinPath(), DefaultDaprDashboardDirname)
 }
 
+func DefaultKessDirPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, DefaultKessDirname)
+}
+
+func DefaultKessRunDirPath() string {
+	return filepath.Join(DefaultKessDirPath(), DefaultKessRunDirname)
+}
+
+func DefaultSecretsFilePath() string {
+	return filepath.Join(DefaultKessDirPath(), DefaultSecretsFilename)
+}
+
+func DefaultAppStateDirPath(appID string) string {
+	return filepath.Join(DefaultKessRunDirPath(), appID)
+}
+
 func DefaultComponentsDirPath() string {
 	return filepath.Join(DefaultDaprDirPath(), DefaultDaprComponentsDirname)
 }
@@ -80,3 +80,81 @@ func CreateRedisPubsubComponent(name string, options RedisPubsubComponentOptions
 	},
 	)
 }
+
+type RedisConfigurationComponentOptions struct {
+	Host     string
+	Password string
+}
+
+func CreateRedisConfigurationComponent(name string, options RedisConfigurationComponentOptions) Component {
+	return CreateComponent(name, ComponentSpec{
+		Type: "configuration.redis",
+		Metadata: []ComponentSpecMetadataItem{
+			{
+				Name:  "redisHost",
+				Value: options.Host,
+			},
+			{
+				Name:  "redisPassword",
+				Value: options.Password,
+			},
+		},
+	},
+	)
+}
+
+type LocalFileSecretStoreComponentOptions struct {
+	SecretsFile     string
+	NestedSeparator string
+}
+
+func CreateLocalFileSecretStoreComponent(name string, options LocalFileSecretStoreComponentOptions) Component {
+	return CreateComponent(name, ComponentSpec{
+		Type: "secretstores.local.file",
+		Metadata: []ComponentSpecMetadataItem{
+			{
+				Name:  "secretsFile",
+				Value: options.SecretsFile,
+			},
+			{
+				Name:  "nestedSeparator",
+				Value: options.NestedSeparator,
+			},
+		},
+	},
+	)
+}
+
+type CronBindingComponentOptions struct {
+	Schedule string
+}
+
+func CreateCronBindingComponent(name string, options CronBindingComponentOptions) Component {
+	return CreateComponent(name, ComponentSpec{
+		Type: "bindings.cron",
+		Metadata: []ComponentSpecMetadataItem{
+			{
+				Name:  "schedule",
+				Value: options.Schedule,
+			},
+		},
+	},
+	)
+}
+
+type HTTPBindingComponentOptions struct {
+	URL string
+}
+
+func CreateHTTPBindingComponent(name string, options HTTPBindingComponentOptions) Component {
+	return CreateComponent(name, ComponentSpec{
+		Type: "bindings.http",
+		Metadata: []ComponentSpecMetadataItem{
+			{
+				Name:  "url",
+				Value: options.URL,
+			},
+		},
+	},
+	)
+}
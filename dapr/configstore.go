@@ -0,0 +1,131 @@
+package dapr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/pkg/errors"
+)
+
+var (
+	DefaultConfigStoreName             = "configstore"
+	DefaultConfigurationSubscribeAppID = "kess-configuration-{Suffix}"
+)
+
+type ConfigurationConfig struct {
+	ClientConfig
+	StoreName string
+	Keys      []string
+}
+
+func (c *ConfigurationConfig) Default() error {
+	if c.StoreName == "" {
+		c.StoreName = DefaultConfigStoreName
+	}
+	return nil
+}
+
+type ConfigurationItem struct {
+	Value    string            `json:"value"`
+	Version  string            `json:"version,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// ConfigurationGet uses the alpha configuration API, which needs a runtime
+// supporting it.
+func ConfigurationGet(ctx context.Context, config *ConfigurationConfig) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(&config.ClientConfig)
+	if err != nil {
+		return err
+	}
+
+	items := map[string]ConfigurationItem{}
+	if _, err := client.DoJSON(ctx, ClientRequest{
+		Method: http.MethodGet,
+		Path:   fmt.Sprintf("/v1.0-alpha1/configuration/%s", config.StoreName),
+		Query:  url.Values{"key": config.Keys},
+	}, nil, &items); err != nil {
+		return err
+	}
+	return printConfigurationItems(items, config.Output)
+}
+
+// ConfigurationSubscribe runs a temporary app, subscribes it to the keys
+// through its own sidecar, and prints the updates the sidecar sends to it.
+func ConfigurationSubscribe(ctx context.Context, config *ConfigurationConfig) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+	if config.Output == "" {
+		config.Output = LogOutputText
+	}
+	appID := tempAppID(DefaultConfigurationSubscribeAppID)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/configuration/", func(w http.ResponseWriter, r *http.Request) {
+		buf, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		update := struct {
+			ID    string                       `json:"id"`
+			Items map[string]ConfigurationItem `json:"items"`
+		}{}
+		if err := json.Unmarshal(buf, &update); err != nil {
+			fmt.Fprintln(os.Stdout, string(buf))
+			return
+		}
+		printConfigurationItems(update.Items, config.Output)
+	})
+
+	print.InfoStatusEvent(os.Stdout, "Subscribing to configuration of %s as app %s", config.StoreName, appID)
+	return runTempApp(ctx, nil, appID, "", mux, func(state *AppState) error {
+		client, err := NewClient(&ClientConfig{Address: fmt.Sprintf("localhost:%d", state.DaprHTTPPort)})
+		if err != nil {
+			return err
+		}
+		result := struct {
+			ID string `json:"id"`
+		}{}
+		if _, err := client.DoJSON(ctx, ClientRequest{
+			Method: http.MethodGet,
+			Path:   fmt.Sprintf("/v1.0-alpha1/configuration/%s/subscribe", config.StoreName),
+			Query:  url.Values{"key": config.Keys},
+		}, nil, &result); err != nil {
+			return errors.Wrap(err, "Error subscribing to configuration")
+		}
+		print.SuccessStatusEvent(os.Stdout, "Subscribed with id %s", result.ID)
+		return nil
+	})
+}
+
+func printConfigurationItems(items map[string]ConfigurationItem, output string) error {
+	if output == LogOutputJSON {
+		buf, err := json.Marshal(items)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		fmt.Fprintln(os.Stdout, string(buf))
+		return nil
+	}
+
+	values := map[string]string{}
+	for k, item := range items {
+		values[k] = item.Value
+		if item.Version != "" {
+			values[k] = fmt.Sprintf("%s\t%s", item.Value, item.Version)
+		}
+	}
+	return printValues(values, output)
+}
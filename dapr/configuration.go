@@ -14,12 +14,19 @@ type ConfigurationSpec struct {
 type ConfigurationSpecTracing struct {
 	SamplingRate string                         `yaml:"samplingRate,omitempty"`
 	Zipkin       ConfigurationSpecTracingZipkin `yaml:"zipkin,omitempty"`
+	Otel         ConfigurationSpecTracingOtel   `yaml:"otel,omitempty"`
 }
 
 type ConfigurationSpecTracingZipkin struct {
 	EndpointAddress string `yaml:"endpointAddress,omitempty"`
 }
 
+type ConfigurationSpecTracingOtel struct {
+	EndpointAddress string `yaml:"endpointAddress,omitempty"`
+	IsSecure        bool   `yaml:"isSecure"`
+	Protocol        string `yaml:"protocol,omitempty"`
+}
+
 func CreateConfiguration(name string, spec ConfigurationSpec) Configuration {
 	return Configuration{
 		APIVersion: "dapr.io/v1alpha1",
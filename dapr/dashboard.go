@@ -1,10 +1,10 @@
 package dapr
 
 import (
-	"os"
+	"context"
 
-	"github.com/dapr/cli/pkg/print"
 	"github.com/dapr/cli/pkg/standalone"
+	"github.com/pkg/errors"
 )
 
 type DashboardRunConfig struct {
@@ -18,13 +18,29 @@ func (c *DashboardRunConfig) Default() error {
 	return nil
 }
 
-func DashboardRun(config *DashboardRunConfig) {
+func DashboardRun(ctx context.Context, config *DashboardRunConfig) error {
 	if err := config.Default(); err != nil {
-		print.FailureStatusEvent(os.Stderr, err.Error())
+		return err
 	}
 
-	err := standalone.NewDashboardCmd(config.Port).Run()
-	if err != nil {
-		print.FailureStatusEvent(os.Stdout, "Dapr dashboard not found. Is Dapr installed?")
+	cmd := standalone.NewDashboardCmd(config.Port)
+	if err := cmd.Start(); err != nil {
+		return errors.Wrap(err, "Dapr dashboard not found. Is Dapr installed?")
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	select {
+	case err := <-exited:
+		return errors.WithStack(err)
+	case <-ctx.Done():
+		if err := cmd.Process.Kill(); err != nil {
+			return errors.WithStack(err)
+		}
+		<-exited
+		return ctx.Err()
 	}
 }
@@ -0,0 +1,77 @@
+package dapr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	DefaultDebugDelve = "dlv"
+)
+
+// DebugConfig runs the app under a headless Delve server that debuggers
+// attach to on the debug port.
+type DebugConfig struct {
+	Enabled bool
+	Port    int
+	// Wait keeps the app from starting until a debugger attached.
+	Wait  bool
+	Delve string
+}
+
+func (c *DebugConfig) Default() error {
+	if c.Port > 0 {
+		c.Enabled = true
+	}
+	if !c.Enabled {
+		return nil
+	}
+	if c.Port <= 0 {
+		c.Port = DefaultDebugPort
+	}
+	if c.Delve == "" {
+		c.Delve = DefaultDebugDelve
+	}
+	return nil
+}
+
+// Command wraps the app command with Delve listening on the host. A go run
+// command is debugged from source, anything else is executed as a binary.
+func (c *DebugConfig) Command(args []string, host string) ([]string, error) {
+	if len(args) == 0 {
+		return nil, errors.New("Debugging needs an app command")
+	}
+
+	cmd := []string{c.Delve}
+	if len(args) >= 2 && args[0] == "go" && args[1] == "run" {
+		pkg, rest := ".", args[2:]
+		if len(rest) > 0 {
+			if strings.HasPrefix(rest[0], "-") {
+				return nil, errors.New("Debugging go run with build flags is not supported, build the app and run the binary instead")
+			}
+			pkg, rest = rest[0], rest[1:]
+		}
+		cmd = append(cmd, "debug", pkg)
+		args = rest
+	} else {
+		cmd = append(cmd, "exec", args[0])
+		args = args[1:]
+	}
+
+	cmd = append(cmd,
+		"--headless",
+		fmt.Sprintf("--listen=%s:%d", host, c.Port),
+		"--api-version=2",
+		"--accept-multiclient",
+	)
+	if !c.Wait {
+		cmd = append(cmd, "--continue")
+	}
+	if len(args) > 0 {
+		cmd = append(cmd, "--")
+		cmd = append(cmd, args...)
+	}
+	return cmd, nil
+}
@@ -0,0 +1,98 @@
+package dapr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDebugConfigDefault(t *testing.T) {
+	tests := []struct {
+		name   string
+		config DebugConfig
+		want   DebugConfig
+	}{
+		{name: "disabled", config: DebugConfig{}, want: DebugConfig{}},
+		{name: "enabled", config: DebugConfig{Enabled: true}, want: DebugConfig{Enabled: true, Port: DefaultDebugPort, Delve: DefaultDebugDelve}},
+		{name: "port enables", config: DebugConfig{Port: 4000}, want: DebugConfig{Enabled: true, Port: 4000, Delve: DefaultDebugDelve}},
+		{name: "custom delve", config: DebugConfig{Enabled: true, Delve: "/go/bin/dlv"}, want: DebugConfig{Enabled: true, Port: DefaultDebugPort, Delve: "/go/bin/dlv"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Default(); err != nil {
+				t.Fatalf("Default() error = %v", err)
+			}
+			if tt.config != tt.want {
+				t.Errorf("Default() = %+v, want %+v", tt.config, tt.want)
+			}
+		})
+	}
+}
+
+func TestDebugConfigCommand(t *testing.T) {
+	config := DebugConfig{Enabled: true, Port: 2345, Delve: "dlv"}
+	waiting := DebugConfig{Enabled: true, Port: 2345, Delve: "dlv", Wait: true}
+	headless := []string{"--headless", "--listen=127.0.0.1:2345", "--api-version=2", "--accept-multiclient"}
+
+	tests := []struct {
+		name    string
+		config  DebugConfig
+		args    []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "binary",
+			config: config,
+			args:   []string{"./app"},
+			want:   append(append([]string{"dlv", "exec", "./app"}, headless...), "--continue"),
+		},
+		{
+			name:   "binary with args",
+			config: config,
+			args:   []string{"./app", "--port", "8080"},
+			want:   append(append([]string{"dlv", "exec", "./app"}, headless...), "--continue", "--", "--port", "8080"),
+		},
+		{
+			name:   "wait for a debugger",
+			config: waiting,
+			args:   []string{"./app"},
+			want:   append([]string{"dlv", "exec", "./app"}, headless...),
+		},
+		{
+			name:   "go run",
+			config: config,
+			args:   []string{"go", "run"},
+			want:   append(append([]string{"dlv", "debug", "."}, headless...), "--continue"),
+		},
+		{
+			name:   "go run with package and args",
+			config: config,
+			args:   []string{"go", "run", "./cmd/app", "-v"},
+			want:   append(append([]string{"dlv", "debug", "./cmd/app"}, headless...), "--continue", "--", "-v"),
+		},
+		{
+			name:    "go run with build flags",
+			config:  config,
+			args:    []string{"go", "run", "-race", "."},
+			wantErr: true,
+		},
+		{
+			name:    "no command",
+			config:  config,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := tt.config.Command(tt.args, "127.0.0.1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Command() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(cmd, tt.want) {
+				t.Errorf("Command() = %v, want %v", cmd, tt.want)
+			}
+		})
+	}
+}
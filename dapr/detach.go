@@ -0,0 +1,161 @@
+package dapr
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/pkg/errors"
+)
+
+const (
+	DetachedEnv = "KESS_DETACHED"
+)
+
+var (
+	detachPollInterval = 200 * time.Millisecond
+)
+
+func IsDetached() bool {
+	return os.Getenv(DetachedEnv) != ""
+}
+
+// StandaloneDetach runs the same kess command again as a background
+// supervisor and returns once it has started Dapr and the app.
+func StandaloneDetach(ctx context.Context, config *StandaloneRunConfig) error {
+	if state, err := LoadAppState(config.AppID); err == nil && ProcessAlive(state.PID) {
+		return errors.Errorf("App %s is already running with pid %d", config.AppID, state.PID)
+	}
+	if err := RemoveAppState(config.AppID); err != nil {
+		return err
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	dir := DefaultAppStateDirPath(config.AppID)
+	logFile, err := OpenLogFile(filepath.Join(dir, DefaultKessLogFilename))
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(executable, os.Args[1:]...)
+	cmd.Env = append(os.Environ(), DetachedEnv+"=1")
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	SetupDetachedProcess(cmd)
+	if err := cmd.Start(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	timeout := time.After(time.Duration(config.AppWaitTimeoutInSeconds) * time.Second)
+	ticker := time.NewTicker(detachPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-exited:
+			return errors.Errorf("App %s exited with status %d before it was up, see %s", config.AppID, ExitCode(err), logFile.Name())
+		case <-timeout:
+			return errors.Errorf("Timed out waiting for app %s to start, see %s", config.AppID, logFile.Name())
+		case <-ticker.C:
+			state, err := LoadAppState(config.AppID)
+			if err != nil {
+				continue
+			}
+			print.SuccessStatusEvent(os.Stdout, "App %s is running in the background with pid %d. HTTP Port: %v. gRPC Port: %v", state.AppID, state.PID, state.DaprHTTPPort, state.DaprGRPCPort)
+			print.InfoStatusEvent(os.Stdout, "Dapr logs: %s", state.DaprLogFile)
+			if state.AppPID != 0 {
+				print.InfoStatusEvent(os.Stdout, "App logs: %s", state.AppLogFile)
+			}
+			return errors.WithStack(cmd.Process.Release())
+		}
+	}
+}
+
+type StandaloneStopConfig struct {
+	AppID                        string
+	ShutdownGracePeriodInSeconds int
+}
+
+func (c *StandaloneStopConfig) Default() error {
+	if c.ShutdownGracePeriodInSeconds == 0 {
+		c.ShutdownGracePeriodInSeconds = DefaultShutdownGracePeriodInSeconds
+	}
+	return nil
+}
+
+// StandaloneStop asks the supervisor of a detached app to shut down, and kills
+// everything left once the supervisor had time for its own graceful shutdown.
+// The pids of Dapr and the app are only trusted while their supervisor is
+// alive, after it is gone they may belong to unrelated processes already.
+func StandaloneStop(ctx context.Context, config *StandaloneStopConfig) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+
+	state, err := LoadAppState(config.AppID)
+	if err != nil {
+		return err
+	}
+
+	if !ProcessAlive(state.PID) {
+		print.WarningStatusEvent(os.Stdout, "The supervisor of app %s with pid %d is gone, removing its stale state", state.AppID, state.PID)
+		return RemoveAppState(state.AppID)
+	}
+
+	supervisor, err := os.FindProcess(state.PID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	print.InfoStatusEvent(os.Stdout, "Stopping app %s", state.AppID)
+	if err := ShutdownProcess(supervisor); err != nil {
+		return err
+	}
+
+	gracePeriod := time.Duration(config.ShutdownGracePeriodInSeconds) * time.Second
+	deadline := time.Now().Add(2 * gracePeriod)
+	for ProcessAlive(state.PID) && time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(detachPollInterval):
+		}
+	}
+
+	if ProcessAlive(state.PID) {
+		for _, pid := range []int{state.AppPID, state.DaprPID, state.PID} {
+			if pid == 0 || !ProcessAlive(pid) {
+				continue
+			}
+			print.WarningStatusEvent(os.Stdout, "Process %d did not exit within %s, killing it", pid, 2*gracePeriod)
+			process, err := os.FindProcess(pid)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if err := KillProcess(process); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := RemoveAppState(state.AppID); err != nil {
+		return err
+	}
+	print.SuccessStatusEvent(os.Stdout, "Stopped app %s", state.AppID)
+	return nil
+}
@@ -0,0 +1,33 @@
+package dapr
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestStandaloneStopStaleState(t *testing.T) {
+	home := os.Getenv("HOME")
+	defer os.Setenv("HOME", home)
+	os.Setenv("HOME", t.TempDir())
+
+	// The test binary running no tests exits right away.
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	if err := cmd.Run(); err != nil {
+		t.Skip(err)
+	}
+
+	// The supervisor is gone, the pid of Dapr now belongs to the test.
+	state := &AppState{AppID: "stale", PID: cmd.Process.Pid, DaprPID: os.Getpid()}
+	if err := SaveAppState(state); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := StandaloneStop(context.Background(), &StandaloneStopConfig{AppID: "stale"}); err != nil {
+		t.Fatalf("StandaloneStop() error = %v", err)
+	}
+	if _, err := LoadAppState("stale"); err == nil {
+		t.Errorf("StandaloneStop() kept the stale state")
+	}
+}
@@ -0,0 +1,106 @@
+package dapr
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type InvokeConfig struct {
+	ClientConfig
+	AppID    string
+	Method   string
+	Verb     string
+	Data     string
+	DataFile string
+	Headers  []string
+}
+
+func (c *InvokeConfig) Default() error {
+	if c.Verb == "" {
+		c.Verb = http.MethodPost
+	}
+	c.Verb = strings.ToUpper(c.Verb)
+	return nil
+}
+
+func Invoke(ctx context.Context, config *InvokeConfig) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(&config.ClientConfig)
+	if err != nil {
+		return err
+	}
+
+	headers, err := parseHeaders(config.Headers)
+	if err != nil {
+		return err
+	}
+
+	body, err := ReadData(config.Data, config.DataFile)
+	if err != nil {
+		return err
+	}
+	if body != nil && headers.Get("Content-Type") == "" {
+		headers.Set("Content-Type", "application/json")
+	}
+
+	// A query in the method goes to the app as the query of the request.
+	method, rawQuery := config.Method, ""
+	if i := strings.Index(method, "?"); i >= 0 {
+		method, rawQuery = method[:i], method[i+1:]
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return errors.Wrapf(err, "Invalid query in method %s", config.Method)
+	}
+
+	resp, err := client.Do(ctx, ClientRequest{
+		Method:  config.Verb,
+		Path:    "/v1.0/invoke/" + config.AppID + "/method/" + strings.TrimPrefix(method, "/"),
+		Query:   query,
+		Headers: headers,
+		Body:    body,
+	})
+	if err != nil {
+		return err
+	}
+	if err := resp.Print(config.Output); err != nil {
+		return err
+	}
+	return resp.Err()
+}
+
+func parseHeaders(values []string) (http.Header, error) {
+	headers := http.Header{}
+	for _, v := range values {
+		kv := strings.SplitN(v, ":", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, errors.Errorf("Invalid header, expected key:value: %s", v)
+		}
+		headers.Add(strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1]))
+	}
+	return headers, nil
+}
+
+func ReadData(data string, dataFile string) ([]byte, error) {
+	switch {
+	case data != "" && dataFile != "":
+		return nil, errors.New("Only one of data and data file can be given")
+	case dataFile != "":
+		buf, err := ioutil.ReadFile(dataFile)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return buf, nil
+	case data != "":
+		return []byte(data), nil
+	}
+	return nil, nil
+}
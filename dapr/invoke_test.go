@@ -0,0 +1,44 @@
+package dapr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestInvokeMethodQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		query  string
+	}{
+		{name: "method", method: "orders", path: "/v1.0/invoke/myapp/method/orders"},
+		{name: "leading slash", method: "/orders/1", path: "/v1.0/invoke/myapp/method/orders/1"},
+		{name: "query", method: "orders?status=open&limit=10", path: "/v1.0/invoke/myapp/method/orders", query: "limit=10&status=open"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path, query string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				path, query = req.URL.Path, req.URL.Query().Encode()
+			}))
+			defer server.Close()
+
+			config := &InvokeConfig{
+				ClientConfig: ClientConfig{Address: strings.TrimPrefix(server.URL, "http://"), Output: LogOutputJSON},
+				AppID:        "myapp",
+				Method:       tt.method,
+			}
+			if err := Invoke(context.Background(), config); err != nil {
+				t.Fatalf("Invoke() error = %v", err)
+			}
+			if path != tt.path || query != tt.query {
+				t.Errorf("Invoke() requested %s?%s, want %s?%s", path, query, tt.path, tt.query)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package dapr
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/pkg/errors"
+)
+
+const (
+	LogOutputText = "text"
+	LogOutputJSON = "json"
+)
+
+var (
+	LogLevels = []string{"debug", "info", "warn", "error", "fatal", "panic"}
+
+	logRecordKeys = []string{"time", "level", "scope", "app_id", "msg"}
+)
+
+type LogRecord struct {
+	Time   string
+	Level  string
+	Scope  string
+	AppID  string
+	Msg    string
+	Fields map[string]string
+}
+
+// ParseLogRecord parses a daprd log line written either as logfmt or as JSON
+// (--log-as-json). Lines without level and msg are not records.
+func ParseLogRecord(line string) (*LogRecord, bool) {
+	fields := map[string]string{}
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "{") {
+		values := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(trimmed), &values); err != nil {
+			return nil, false
+		}
+		for k, v := range values {
+			if s, ok := v.(string); ok {
+				fields[k] = s
+			} else {
+				fields[k] = fmt.Sprint(v)
+			}
+		}
+	} else if err := parseLogfmt(trimmed, fields); err != nil {
+		return nil, false
+	}
+
+	if fields["level"] == "" || fields["msg"] == "" {
+		return nil, false
+	}
+
+	record := &LogRecord{
+		Time:  fields["time"],
+		Level: fields["level"],
+		Scope: fields["scope"],
+		AppID: fields["app_id"],
+		Msg:   fields["msg"],
+	}
+	for _, k := range logRecordKeys {
+		delete(fields, k)
+	}
+	record.Fields = fields
+	return record, true
+}
+
+func (r *LogRecord) Map() map[string]string {
+	m := map[string]string{}
+	for k, v := range r.Fields {
+		m[k] = v
+	}
+	m["time"] = r.Time
+	m["level"] = r.Level
+	m["scope"] = r.Scope
+	m["app_id"] = r.AppID
+	m["msg"] = r.Msg
+	return m
+}
+
+func parseLogfmt(line string, fields map[string]string) error {
+	for len(line) > 0 {
+		line = strings.TrimLeft(line, " ")
+		if line == "" {
+			break
+		}
+		eq := strings.IndexAny(line, "= ")
+		if eq <= 0 || line[eq] != '=' {
+			return errors.Errorf("Invalid logfmt: %s", line)
+		}
+		key := line[:eq]
+		line = line[eq+1:]
+
+		var value string
+		if strings.HasPrefix(line, `"`) {
+			end := 1
+			for end < len(line) && line[end] != '"' {
+				if line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(line) {
+				return errors.Errorf("Unterminated logfmt value: %s", line)
+			}
+			unquoted, err := strconv.Unquote(line[:end+1])
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			value = unquoted
+			line = line[end+1:]
+		} else {
+			end := strings.IndexByte(line, ' ')
+			if end < 0 {
+				end = len(line)
+			}
+			value = line[:end]
+			line = line[end:]
+		}
+		fields[key] = value
+	}
+	return nil
+}
+
+type LogPipeline struct {
+	Level  string
+	Scope  string
+	AppID  string
+	Output string
+}
+
+func (p *LogPipeline) Default() error {
+	if p.Output == "" {
+		p.Output = LogOutputText
+	}
+	if p.Output != LogOutputText && p.Output != LogOutputJSON {
+		return errors.Errorf("Unknown log output: %s", p.Output)
+	}
+	if p.Level != "" && logLevelIndex(p.Level) < 0 {
+		return errors.Errorf("Unknown log level: %s", p.Level)
+	}
+	return nil
+}
+
+// Enabled reports whether lines need to go through the pipeline at all, so
+// callers can keep copying raw output otherwise.
+func (p *LogPipeline) Enabled() bool {
+	return p.Level != "" || p.Scope != "" || p.AppID != "" || p.Output == LogOutputJSON
+}
+
+func (p *LogPipeline) Match(record *LogRecord) bool {
+	if p.Level != "" && logLevelIndex(record.Level) < logLevelIndex(p.Level) {
+		return false
+	}
+	if p.Scope != "" && !strings.HasPrefix(record.Scope, p.Scope) {
+		return false
+	}
+	if p.AppID != "" && record.AppID != p.AppID {
+		return false
+	}
+	return true
+}
+
+// Format returns the line to print and whether it should be printed at all.
+// Lines which are not daprd records are passed through untouched.
+func (p *LogPipeline) Format(line string) (string, bool) {
+	record, ok := ParseLogRecord(line)
+	if !ok {
+		if p.Output == LogOutputJSON {
+			buf, _ := json.Marshal(map[string]string{"msg": line})
+			return string(buf), true
+		}
+		return line, true
+	}
+
+	if !p.Match(record) {
+		return "", false
+	}
+
+	if p.Output == LogOutputJSON {
+		buf, _ := json.Marshal(record.Map())
+		return string(buf), true
+	}
+
+	keys := make([]string, 0, len(record.Fields))
+	for k := range record.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-30s %s %-24s %-16s %s", record.Time, colorLogLevel(record.Level), record.Scope, record.AppID, record.Msg)
+	for _, k := range keys {
+		fmt.Fprintf(&b, " %s=%s", k, record.Fields[k])
+	}
+	return b.String(), true
+}
+
+func logLevelIndex(level string) int {
+	level = strings.ToLower(level)
+	if level == "warning" {
+		level = "warn"
+	}
+	for i, l := range LogLevels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
+
+func colorLogLevel(level string) string {
+	s := fmt.Sprintf("%-5s", strings.ToUpper(level))
+	switch i := logLevelIndex(level); {
+	case i >= logLevelIndex("error"):
+		return print.Red(s)
+	case i == logLevelIndex("warn"):
+		return print.Yellow(s)
+	default:
+		return s
+	}
+}
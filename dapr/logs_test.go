@@ -0,0 +1,160 @@
+package dapr
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseLogRecord(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		record *LogRecord
+	}{
+		{
+			name: "logfmt",
+			line: `time="2021-03-01T10:00:00.000Z" level=info msg="starting Dapr Runtime" app_id=myapp instance=host scope=dapr.runtime type=log ver=1.0.1`,
+			record: &LogRecord{
+				Time:   "2021-03-01T10:00:00.000Z",
+				Level:  "info",
+				Scope:  "dapr.runtime",
+				AppID:  "myapp",
+				Msg:    "starting Dapr Runtime",
+				Fields: map[string]string{"instance": "host", "type": "log", "ver": "1.0.1"},
+			},
+		},
+		{
+			name: "logfmt with escaped quotes",
+			line: `level=warn msg="component \"redis\" not ready" scope=dapr.runtime`,
+			record: &LogRecord{
+				Level:  "warn",
+				Scope:  "dapr.runtime",
+				Msg:    `component "redis" not ready`,
+				Fields: map[string]string{},
+			},
+		},
+		{
+			name: "json",
+			line: `{"time":"2021-03-01T10:00:00.000Z","level":"error","msg":"failed","app_id":"myapp","scope":"dapr.runtime.actor","port":3500}`,
+			record: &LogRecord{
+				Time:   "2021-03-01T10:00:00.000Z",
+				Level:  "error",
+				Scope:  "dapr.runtime.actor",
+				AppID:  "myapp",
+				Msg:    "failed",
+				Fields: map[string]string{"port": "3500"},
+			},
+		},
+		{name: "plain text", line: "Listening on :8080"},
+		{name: "logfmt without msg", line: "level=info scope=dapr.runtime"},
+		{name: "unterminated quote", line: `level=info msg="starting`},
+		{name: "invalid json", line: `{"level":"info",`},
+		{name: "empty", line: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record, ok := ParseLogRecord(tt.line)
+			if ok != (tt.record != nil) {
+				t.Fatalf("ParseLogRecord() ok = %v, want %v", ok, tt.record != nil)
+			}
+			if !reflect.DeepEqual(record, tt.record) {
+				t.Errorf("ParseLogRecord() = %+v, want %+v", record, tt.record)
+			}
+		})
+	}
+}
+
+func TestLogPipelineMatch(t *testing.T) {
+	record := &LogRecord{Level: "warn", Scope: "dapr.runtime.actor", AppID: "myapp", Msg: "msg"}
+	tests := []struct {
+		name     string
+		pipeline LogPipeline
+		match    bool
+	}{
+		{name: "no filters", pipeline: LogPipeline{}, match: true},
+		{name: "same level", pipeline: LogPipeline{Level: "warn"}, match: true},
+		{name: "lower level", pipeline: LogPipeline{Level: "debug"}, match: true},
+		{name: "higher level", pipeline: LogPipeline{Level: "error"}, match: false},
+		{name: "scope prefix", pipeline: LogPipeline{Scope: "dapr.runtime"}, match: true},
+		{name: "other scope", pipeline: LogPipeline{Scope: "dapr.placement"}, match: false},
+		{name: "same app", pipeline: LogPipeline{AppID: "myapp"}, match: true},
+		{name: "other app", pipeline: LogPipeline{AppID: "other"}, match: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if match := tt.pipeline.Match(record); match != tt.match {
+				t.Errorf("Match() = %v, want %v", match, tt.match)
+			}
+		})
+	}
+}
+
+func TestLogPipelineDefault(t *testing.T) {
+	tests := []struct {
+		name     string
+		pipeline LogPipeline
+		wantErr  bool
+	}{
+		{name: "empty", pipeline: LogPipeline{}},
+		{name: "json", pipeline: LogPipeline{Output: LogOutputJSON, Level: "error"}},
+		{name: "unknown output", pipeline: LogPipeline{Output: "yaml"}, wantErr: true},
+		{name: "unknown level", pipeline: LogPipeline{Level: "verbose"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.pipeline.Default(); (err != nil) != tt.wantErr {
+				t.Errorf("Default() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLogPipelineFormatJSON(t *testing.T) {
+	pipeline := LogPipeline{Output: LogOutputJSON, Level: "info"}
+	tests := []struct {
+		name  string
+		line  string
+		want  map[string]string
+		print bool
+	}{
+		{
+			name:  "record",
+			line:  `level=info msg=started scope=dapr.runtime app_id=myapp`,
+			want:  map[string]string{"time": "", "level": "info", "scope": "dapr.runtime", "app_id": "myapp", "msg": "started"},
+			print: true,
+		},
+		{
+			name:  "plain text",
+			line:  "hello",
+			want:  map[string]string{"msg": "hello"},
+			print: true,
+		},
+		{
+			name: "filtered",
+			line: `level=debug msg=noise`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, ok := pipeline.Format(tt.line)
+			if ok != tt.print {
+				t.Fatalf("Format() ok = %v, want %v", ok, tt.print)
+			}
+			if !ok {
+				return
+			}
+			got := map[string]string{}
+			if err := json.Unmarshal([]byte(text), &got); err != nil {
+				t.Fatalf("Format() = %s, not JSON: %s", text, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Format() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package dapr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type MetricsConfig struct {
+	PrometheusAddress string
+	Output            string
+}
+
+func (c *MetricsConfig) Default() error {
+	if c.PrometheusAddress == "" {
+		c.PrometheusAddress = DefaultPrometheusAddress
+	}
+	if c.Output == "" {
+		c.Output = LogOutputText
+	}
+	if c.Output != LogOutputText && c.Output != LogOutputJSON {
+		return errors.Errorf("Unknown output: %s", c.Output)
+	}
+	return nil
+}
+
+type Metric struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Value  string            `json:"value"`
+}
+
+// The queries are run with {Selector} replaced by the label selector of the
+// app, latencies of the sidecars are in milliseconds.
+var metricsQueries = []struct {
+	name  string
+	query string
+}{
+	{"http_requests_total", `sum by (method, status) (dapr_http_server_request_count{Selector})`},
+	{"http_requests_per_second", `sum(rate(dapr_http_server_request_count{Selector}[1m]))`},
+	{"http_latency_p50_ms", `histogram_quantile(0.5, sum by (le) (rate(dapr_http_server_latency_bucket{Selector}[5m])))`},
+	{"http_latency_p99_ms", `histogram_quantile(0.99, sum by (le) (rate(dapr_http_server_latency_bucket{Selector}[5m])))`},
+	{"grpc_requests_total", `sum by (grpc_server_method, grpc_server_status) (dapr_grpc_io_server_completed_rpcs{Selector})`},
+	{"grpc_latency_p99_ms", `histogram_quantile(0.99, sum by (le) (rate(dapr_grpc_io_server_server_latency_bucket{Selector}[5m])))`},
+	{"components_loaded", `sum(dapr_runtime_component_loaded{Selector})`},
+	{"component_init_errors", `sum by (component, reason) (dapr_runtime_component_init_fail_total{Selector})`},
+}
+
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		Result []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []interface{}     `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// Metrics prints the key counters of the sidecar of an app as scraped by
+// the Prometheus kess installs.
+func Metrics(ctx context.Context, config *MetricsConfig, appID string) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(&ClientConfig{Address: config.PrometheusAddress, Output: config.Output})
+	if err != nil {
+		return err
+	}
+
+	selector := fmt.Sprintf("{app_id=%q}", appID)
+	metrics := []Metric{}
+	for _, q := range metricsQueries {
+		query := url.Values{}
+		query.Set("query", strings.ReplaceAll(q.query, "{Selector}", selector))
+		resp := prometheusResponse{}
+		if _, err := client.DoJSON(ctx, ClientRequest{Method: http.MethodGet, Path: "/api/v1/query", Query: query}, nil, &resp); err != nil {
+			return errors.Wrap(err, "Error querying Prometheus")
+		}
+		if resp.Status != "success" {
+			return errors.Errorf("Error querying Prometheus: %s", resp.Error)
+		}
+		for _, result := range resp.Data.Result {
+			if len(result.Value) != 2 {
+				continue
+			}
+			value, _ := result.Value[1].(string)
+			metrics = append(metrics, Metric{Name: q.name, Labels: result.Metric, Value: value})
+		}
+	}
+
+	if config.Output == LogOutputJSON {
+		return printJSON(metrics)
+	}
+	if len(metrics) == 0 {
+		return errors.Errorf("No metrics of app %s, is the app running and Prometheus installed", appID)
+	}
+	for _, metric := range metrics {
+		fmt.Fprintf(os.Stdout, "%-28s %-12s %s\n", metric.Name, metric.Value, formatMetricLabels(metric.Labels))
+	}
+	return nil
+}
+
+func formatMetricLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, labels[k]))
+	}
+	return strings.Join(pairs, " ")
+}
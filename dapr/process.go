@@ -0,0 +1,45 @@
+// +build !windows
+
+package dapr
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+func SetupProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+func TerminateProcess(process *os.Process) error {
+	return signalProcessGroup(process, syscall.SIGTERM)
+}
+
+// ShutdownProcess asks only the process itself to exit, not its group.
+func ShutdownProcess(process *os.Process) error {
+	return errors.WithStack(process.Signal(syscall.SIGTERM))
+}
+
+func KillProcess(process *os.Process) error {
+	return signalProcessGroup(process, syscall.SIGKILL)
+}
+
+func signalProcessGroup(process *os.Process, sig syscall.Signal) error {
+	if err := syscall.Kill(-process.Pid, sig); err != nil && err != syscall.ESRCH {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// SetupDetachedProcess starts the process in a new session, so it survives
+// the terminal kess was started from.
+func SetupDetachedProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
+
+func ProcessAlive(pid int) bool {
+	return pid > 0 && syscall.Kill(pid, 0) == nil
+}
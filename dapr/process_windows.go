@@ -0,0 +1,51 @@
+package dapr
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/windows"
+)
+
+// STILL_ACTIVE exit code, reported while the process is running.
+const windowsStillActive = 259
+
+func SetupProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// TerminateProcess kills the process right away, there is no SIGTERM to send
+// to another console process group on Windows.
+func TerminateProcess(process *os.Process) error {
+	return KillProcess(process)
+}
+
+func ShutdownProcess(process *os.Process) error {
+	return KillProcess(process)
+}
+
+func KillProcess(process *os.Process) error {
+	return errors.WithStack(process.Kill())
+}
+
+func SetupDetachedProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP | windows.DETACHED_PROCESS}
+}
+
+func ProcessAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer windows.CloseHandle(handle)
+	var code uint32
+	if err := windows.GetExitCodeProcess(handle, &code); err != nil {
+		return false
+	}
+	return code == windowsStillActive
+}
@@ -0,0 +1,181 @@
+package dapr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/pkg/errors"
+)
+
+var (
+	DefaultPubsubName     = "pubsub"
+	DefaultSubscribeAppID = "kess-subscribe-{Suffix}"
+	DefaultSubscribeRoute = "/events"
+)
+
+type PublishConfig struct {
+	ClientConfig
+	PubsubName string
+	Topic      string
+	Data       string
+	DataFile   string
+	Metadata   []string
+}
+
+func (c *PublishConfig) Default() error {
+	if c.PubsubName == "" {
+		c.PubsubName = DefaultPubsubName
+	}
+	if c.Topic == "" {
+		return errors.New("Topic is required")
+	}
+	return nil
+}
+
+// Publish sends data to a topic, the sidecar wraps it in a CloudEvent unless
+// it is one already.
+func Publish(ctx context.Context, config *PublishConfig) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(&config.ClientConfig)
+	if err != nil {
+		return err
+	}
+
+	body, err := ReadData(config.Data, config.DataFile)
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	for _, m := range config.Metadata {
+		kv := strings.SplitN(m, "=", 2)
+		if len(kv) != 2 {
+			return errors.Errorf("Invalid metadata, expected key=value: %s", m)
+		}
+		query.Set("metadata."+kv[0], kv[1])
+	}
+
+	headers := http.Header{}
+	if len(body) > 0 {
+		headers.Set("Content-Type", "application/json")
+		if json.Valid(body) && strings.Contains(string(body), `"specversion"`) {
+			headers.Set("Content-Type", "application/cloudevents+json")
+		}
+	}
+
+	resp, err := client.Do(ctx, ClientRequest{
+		Method:  http.MethodPost,
+		Path:    fmt.Sprintf("/v1.0/publish/%s/%s", config.PubsubName, config.Topic),
+		Query:   query,
+		Headers: headers,
+		Body:    body,
+	})
+	if err != nil {
+		return err
+	}
+	if err := resp.Err(); err != nil {
+		return err
+	}
+	if config.Output != LogOutputJSON {
+		print.SuccessStatusEvent(os.Stdout, "Event published to topic %s of %s", config.Topic, config.PubsubName)
+	}
+	return nil
+}
+
+type SubscribeConfig struct {
+	AppID      string
+	PubsubName string
+	Topic      string
+	Output     string
+	Runner     TempAppRunner
+}
+
+func (c *SubscribeConfig) Default() error {
+	if c.AppID == "" {
+		c.AppID = tempAppID(DefaultSubscribeAppID)
+	}
+	if c.PubsubName == "" {
+		c.PubsubName = DefaultPubsubName
+	}
+	if c.Topic == "" {
+		return errors.New("Topic is required")
+	}
+	if c.Output == "" {
+		c.Output = LogOutputText
+	}
+	if c.Output != LogOutputText && c.Output != LogOutputJSON {
+		return errors.Errorf("Unknown output: %s", c.Output)
+	}
+	return nil
+}
+
+// Subscribe runs a temporary app which is the subscriber itself, so it shares
+// the pub/sub broker with every other kess app. The runner decides where its
+// sidecar runs.
+func Subscribe(ctx context.Context, config *SubscribeConfig) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/dapr/subscribe", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]map[string]string{{
+			"pubsubname": config.PubsubName,
+			"topic":      config.Topic,
+			"route":      DefaultSubscribeRoute,
+		}})
+	})
+	mux.HandleFunc(DefaultSubscribeRoute, func(w http.ResponseWriter, r *http.Request) {
+		buf, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		printEvent(buf, config.Output)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"SUCCESS"}`))
+	})
+
+	print.InfoStatusEvent(os.Stdout, "Subscribing to topic %s of %s as app %s", config.Topic, config.PubsubName, config.AppID)
+	return runTempApp(ctx, config.Runner, config.AppID, "", mux, nil)
+}
+
+func printEvent(buf []byte, output string) {
+	if output == LogOutputJSON {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, buf); err == nil {
+			fmt.Fprintln(os.Stdout, compact.String())
+			return
+		}
+		fmt.Fprintln(os.Stdout, string(buf))
+		return
+	}
+
+	event := struct {
+		ID    string          `json:"id"`
+		Topic string          `json:"topic"`
+		Time  string          `json:"time"`
+		Data  json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(buf, &event); err != nil {
+		fmt.Fprintln(os.Stdout, string(buf))
+		return
+	}
+	if event.Time == "" {
+		event.Time = time.Now().Format(time.RFC3339)
+	}
+	fmt.Fprintf(os.Stdout, "%s %s %s %s\n", event.Time, print.Blue(event.Topic), event.ID, string(event.Data))
+}
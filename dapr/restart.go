@@ -0,0 +1,90 @@
+package dapr
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	RestartNo        = "no"
+	RestartOnFailure = "on-failure"
+	RestartAlways    = "always"
+)
+
+type RestartPolicy struct {
+	Name       string
+	MaxRetries int
+	Dapr       bool
+}
+
+func (p *RestartPolicy) Default() error {
+	if p.Name == "" {
+		p.Name = RestartNo
+	}
+	switch p.Name {
+	case RestartNo, RestartOnFailure, RestartAlways:
+		return nil
+	default:
+		return errors.Errorf("Unknown restart policy: %s", p.Name)
+	}
+}
+
+// ShouldRestart tells whether a process which exited with code should be
+// started again, given how many times in a row it was restarted already.
+func (p *RestartPolicy) ShouldRestart(code int, retries int) bool {
+	switch p.Name {
+	case RestartAlways:
+	case RestartOnFailure:
+		if code == 0 {
+			return false
+		}
+	default:
+		return false
+	}
+	return p.MaxRetries <= 0 || retries < p.MaxRetries
+}
+
+// Backoff doubles the delay for every restart in a row, up to the max backoff.
+func (p *RestartPolicy) Backoff(retries int) time.Duration {
+	backoff := time.Duration(DefaultRestartBackoffInSeconds) * time.Second
+	maxBackoff := time.Duration(DefaultRestartMaxBackoffInSeconds) * time.Second
+	for i := 1; i < retries && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
+// Stable reports whether a process ran long enough for its retries to be
+// counted from zero again.
+func (p *RestartPolicy) Stable(uptime time.Duration) bool {
+	return uptime >= time.Duration(DefaultRestartMaxBackoffInSeconds)*time.Second
+}
+
+type restartTracker struct {
+	policy    *RestartPolicy
+	startedAt time.Time
+	retries   int
+	restarts  int
+}
+
+func (t *restartTracker) started() {
+	t.startedAt = time.Now()
+}
+
+// exited returns the backoff before restarting, or false if the process
+// should stay down.
+func (t *restartTracker) exited(code int) (time.Duration, bool) {
+	if t.policy.Stable(time.Since(t.startedAt)) {
+		t.retries = 0
+	}
+	if !t.policy.ShouldRestart(code, t.retries) {
+		return 0, false
+	}
+	t.retries++
+	t.restarts++
+	return t.policy.Backoff(t.retries), true
+}
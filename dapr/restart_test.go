@@ -0,0 +1,91 @@
+package dapr
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRestartPolicyShouldRestart(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RestartPolicy
+		code    int
+		retries int
+		restart bool
+	}{
+		{name: "no", policy: RestartPolicy{Name: RestartNo}, code: 1},
+		{name: "on-failure after success", policy: RestartPolicy{Name: RestartOnFailure}, code: 0},
+		{name: "on-failure after failure", policy: RestartPolicy{Name: RestartOnFailure}, code: 1, restart: true},
+		{name: "always after success", policy: RestartPolicy{Name: RestartAlways}, code: 0, restart: true},
+		{name: "under max retries", policy: RestartPolicy{Name: RestartAlways, MaxRetries: 3}, code: 1, retries: 2, restart: true},
+		{name: "at max retries", policy: RestartPolicy{Name: RestartAlways, MaxRetries: 3}, code: 1, retries: 3},
+		{name: "unlimited retries", policy: RestartPolicy{Name: RestartOnFailure}, code: 1, retries: 100, restart: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if restart := tt.policy.ShouldRestart(tt.code, tt.retries); restart != tt.restart {
+				t.Errorf("ShouldRestart(%d, %d) = %v, want %v", tt.code, tt.retries, restart, tt.restart)
+			}
+		})
+	}
+}
+
+func TestRestartPolicyBackoff(t *testing.T) {
+	tests := []struct {
+		retries int
+		backoff time.Duration
+	}{
+		{retries: 0, backoff: 1 * time.Second},
+		{retries: 1, backoff: 1 * time.Second},
+		{retries: 2, backoff: 2 * time.Second},
+		{retries: 3, backoff: 4 * time.Second},
+		{retries: 5, backoff: 16 * time.Second},
+		{retries: 6, backoff: 30 * time.Second},
+		{retries: 100, backoff: 30 * time.Second},
+	}
+
+	policy := RestartPolicy{Name: RestartAlways}
+	for _, tt := range tests {
+		if backoff := policy.Backoff(tt.retries); backoff != tt.backoff {
+			t.Errorf("Backoff(%d) = %s, want %s", tt.retries, backoff, tt.backoff)
+		}
+	}
+}
+
+func TestRestartPolicyDefault(t *testing.T) {
+	policy := RestartPolicy{}
+	if err := policy.Default(); err != nil || policy.Name != RestartNo {
+		t.Errorf("Default() = %v, Name = %q, want %q", err, policy.Name, RestartNo)
+	}
+	policy = RestartPolicy{Name: "sometimes"}
+	if err := policy.Default(); err == nil {
+		t.Errorf("Default() error = nil, want an error")
+	}
+}
+
+func TestRestartTracker(t *testing.T) {
+	tracker := &restartTracker{policy: &RestartPolicy{Name: RestartOnFailure, MaxRetries: 2}}
+
+	tracker.started()
+	if backoff, ok := tracker.exited(1); !ok || backoff != 1*time.Second {
+		t.Fatalf("exited(1) = %s, %v, want 1s, true", backoff, ok)
+	}
+	tracker.started()
+	if backoff, ok := tracker.exited(1); !ok || backoff != 2*time.Second {
+		t.Fatalf("exited(1) = %s, %v, want 2s, true", backoff, ok)
+	}
+	tracker.started()
+	if _, ok := tracker.exited(1); ok {
+		t.Fatalf("exited(1) restarts past max retries")
+	}
+
+	// A process which ran long enough counts its retries from zero again.
+	tracker.startedAt = time.Now().Add(-time.Duration(DefaultRestartMaxBackoffInSeconds) * time.Second)
+	if backoff, ok := tracker.exited(1); !ok || backoff != 1*time.Second {
+		t.Fatalf("exited(1) after a stable run = %s, %v, want 1s, true", backoff, ok)
+	}
+	if tracker.restarts != 3 {
+		t.Errorf("restarts = %d, want 3", tracker.restarts)
+	}
+}
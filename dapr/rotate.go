@@ -0,0 +1,102 @@
+package dapr
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// RotatingFile is a log file which is renamed to <Filename>.1, <Filename>.2
+// and so on once it grows over MaxSize, keeping at most MaxFiles of them.
+type RotatingFile struct {
+	Filename string
+	MaxSize  int64
+	MaxFiles int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+func OpenRotatingFile(filename string, maxSize int64, maxFiles int) (*RotatingFile, error) {
+	f := &RotatingFile{
+		Filename: filename,
+		MaxSize:  maxSize,
+		MaxFiles: maxFiles,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.MaxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.MaxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	if err != nil {
+		return n, errors.WithStack(err)
+	}
+	return n, nil
+}
+
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return errors.WithStack(f.file.Close())
+}
+
+func (f *RotatingFile) open() error {
+	file, err := OpenLogFile(f.Filename)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return errors.WithStack(err)
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if f.MaxFiles <= 0 {
+		if err := os.Remove(f.Filename); err != nil && !os.IsNotExist(err) {
+			return errors.WithStack(err)
+		}
+		return f.open()
+	}
+
+	if err := os.Remove(RotatedFilename(f.Filename, f.MaxFiles)); err != nil && !os.IsNotExist(err) {
+		return errors.WithStack(err)
+	}
+	for i := f.MaxFiles - 1; i >= 1; i-- {
+		if err := os.Rename(RotatedFilename(f.Filename, i), RotatedFilename(f.Filename, i+1)); err != nil && !os.IsNotExist(err) {
+			return errors.WithStack(err)
+		}
+	}
+	if err := os.Rename(f.Filename, RotatedFilename(f.Filename, 1)); err != nil && !os.IsNotExist(err) {
+		return errors.WithStack(err)
+	}
+	return f.open()
+}
+
+func RotatedFilename(filename string, index int) string {
+	return fmt.Sprintf("%s.%d", filename, index)
+}
@@ -0,0 +1,139 @@
+package dapr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	DefaultSecretStoreName       = "secretstore"
+	DefaultSecretNestedSeparator = ":"
+)
+
+type SecretsConfig struct {
+	ClientConfig
+	StoreName string
+}
+
+func (c *SecretsConfig) Default() error {
+	if c.StoreName == "" {
+		c.StoreName = DefaultSecretStoreName
+	}
+	return nil
+}
+
+// SecretsGet reads one secret, or all secrets of the store when key is empty.
+func SecretsGet(ctx context.Context, config *SecretsConfig, key string) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+
+	client, err := NewClient(&config.ClientConfig)
+	if err != nil {
+		return err
+	}
+
+	secrets := map[string]string{}
+	if key != "" {
+		if _, err := client.DoJSON(ctx, ClientRequest{
+			Method: http.MethodGet,
+			Path:   fmt.Sprintf("/v1.0/secrets/%s/%s", config.StoreName, key),
+		}, nil, &secrets); err != nil {
+			return err
+		}
+	} else {
+		bulk := map[string]map[string]string{}
+		if _, err := client.DoJSON(ctx, ClientRequest{
+			Method: http.MethodGet,
+			Path:   fmt.Sprintf("/v1.0/secrets/%s/bulk", config.StoreName),
+		}, nil, &bulk); err != nil {
+			return err
+		}
+		for _, values := range bulk {
+			for k, v := range values {
+				secrets[k] = v
+			}
+		}
+	}
+	return printValues(secrets, config.Output)
+}
+
+func LoadLocalSecrets(filename string) (map[string]string, error) {
+	secrets := map[string]string{}
+	buf, err := ioutil.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return secrets, nil
+		}
+		return nil, errors.WithStack(err)
+	}
+	if err := UnmarshalLocalSecrets(buf, secrets); err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
+
+func UnmarshalLocalSecrets(buf []byte, secrets map[string]string) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(buf, &secrets); err != nil {
+		return errors.Wrap(err, "Secrets file must be a JSON object of strings")
+	}
+	return nil
+}
+
+func MarshalLocalSecrets(secrets map[string]string) ([]byte, error) {
+	buf, err := json.MarshalIndent(secrets, "", "  ")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return buf, nil
+}
+
+func SaveLocalSecrets(filename string, secrets map[string]string) error {
+	buf, err := MarshalLocalSecrets(secrets)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := ioutil.WriteFile(filename, buf, 0600); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// EnsureLocalSecrets creates an empty secrets file, the local file secret
+// store fails to init without one.
+func EnsureLocalSecrets(filename string) error {
+	if _, err := os.Stat(filename); err == nil || !os.IsNotExist(err) {
+		return errors.WithStack(err)
+	}
+	return SaveLocalSecrets(filename, map[string]string{})
+}
+
+func printValues(values map[string]string, output string) error {
+	if output == LogOutputJSON {
+		return printJSON(values)
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(os.Stdout, "%s\t%s\n", k, values[k])
+	}
+	return nil
+}
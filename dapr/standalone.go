@@ -2,18 +2,35 @@ package dapr
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dapr/cli/pkg/metadata"
 	"github.com/dapr/cli/pkg/print"
 	"github.com/dapr/cli/pkg/standalone"
+	cli_ver "github.com/dapr/cli/pkg/version"
 	"github.com/dapr/cli/utils"
+	"github.com/pkg/errors"
 )
 
+func ResolveRuntimeVersion(runtimeVersion string) (string, error) {
+	if runtimeVersion != "" && runtimeVersion != DefaultRuntimeVersion {
+		return strings.TrimPrefix(runtimeVersion, "v"), nil
+	}
+	version, err := cli_ver.GetLatestRelease(cli_ver.DaprGitHubOrg, cli_ver.DaprGitHubRepo)
+	if err != nil {
+		return "", errors.Wrap(err, "Cannot get the latest Dapr runtime version")
+	}
+	return strings.TrimPrefix(version, "v"), nil
+}
+
 func StandaloneInstall(runtimeVersion string, dashboardVersion string) error {
 	if err := standalone.Init(runtimeVersion, dashboardVersion, "", true); err != nil {
 		return err
@@ -21,6 +38,36 @@ func StandaloneInstall(runtimeVersion string, dashboardVersion string) error {
 	return nil
 }
 
+// StandaloneUpgrade reinstalls the Dapr binaries. The Dapr CLI only installs
+// into the bin dir, so the old one is moved aside and restored when the
+// install fails, leaving a working daprd behind either way.
+func StandaloneUpgrade(runtimeVersion string, dashboardVersion string) error {
+	binPath := DefaultDaprBinPath()
+	backupPath := binPath + ".old"
+	if err := os.RemoveAll(backupPath); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := os.Rename(binPath, backupPath); err != nil && !os.IsNotExist(err) {
+		return errors.WithStack(err)
+	}
+	if err := StandaloneInstall(runtimeVersion, dashboardVersion); err != nil {
+		if _, statErr := os.Stat(backupPath); statErr == nil {
+			if restoreErr := restoreDir(backupPath, binPath); restoreErr != nil {
+				return errors.Wrapf(err, "Failed to restore %s from %s: %s", binPath, backupPath, restoreErr)
+			}
+		}
+		return err
+	}
+	return errors.WithStack(os.RemoveAll(backupPath))
+}
+
+func restoreDir(from string, to string) error {
+	if err := os.RemoveAll(to); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.Rename(from, to))
+}
+
 func StandaloneUninstall() error {
 	if err := standalone.Uninstall(false, ""); err != nil {
 		return err
@@ -32,127 +79,207 @@ type StandaloneRunConfig struct {
 	standalone.RunConfig
 	AppWaitTimeoutInSeconds int
 	AppPwd                  string
+	Logs                    LogPipeline
+	Detach                  bool
+	LogMaxSizeInMB          int
+	LogMaxFiles             int
+	Restart                 RestartPolicy
+	Debug                   DebugConfig
+	// DaprCMD replaces the daprd command built by the Dapr CLI, e.g. to run
+	// the sidecar in a container instead of from the host binaries.
+	DaprCMD func(cmd *exec.Cmd) *exec.Cmd
+
+	ShutdownGracePeriodInSeconds int
 }
 
 func (c *StandaloneRunConfig) Default() error {
 	if c.AppWaitTimeoutInSeconds == 0 {
 		c.AppWaitTimeoutInSeconds = DefaultAppWaitTimeoutInSeconds
 	}
+	if c.ShutdownGracePeriodInSeconds == 0 {
+		c.ShutdownGracePeriodInSeconds = DefaultShutdownGracePeriodInSeconds
+	}
+	if c.LogMaxSizeInMB == 0 {
+		c.LogMaxSizeInMB = DefaultLogMaxSizeInMB
+	}
+	if c.LogMaxFiles == 0 {
+		c.LogMaxFiles = DefaultLogMaxFiles
+	}
+	if err := c.Logs.Default(); err != nil {
+		return err
+	}
+	if err := c.Restart.Default(); err != nil {
+		return err
+	}
+	if err := c.Debug.Default(); err != nil {
+		return err
+	}
+	// The app may sit at a breakpoint before it listens.
+	if c.Debug.Enabled && c.AppWaitTimeoutInSeconds < DefaultDebugAppWaitTimeoutInSeconds {
+		c.AppWaitTimeoutInSeconds = DefaultDebugAppWaitTimeoutInSeconds
+	}
 	return nil
 }
 
-func StandaloneRun(config *StandaloneRunConfig) {
+func (c *StandaloneRunConfig) openLogFile(filename string) (*RotatingFile, error) {
+	return OpenRotatingFile(filename, int64(c.LogMaxSizeInMB)*1024*1024, c.LogMaxFiles)
+}
+
+// scanLogs writes every line to the log file, and tees it to the console
+// unless the run is detached.
+func (c *StandaloneRunConfig) scanLogs(wg *sync.WaitGroup, reader io.Reader, file io.Writer, prefix string) {
+	defer wg.Done()
+	buffered := bufio.NewReader(reader)
+	for {
+		line, err := buffered.ReadString('\n')
+		if line == "" && err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		fmt.Fprintln(file, line)
+		if c.Detach {
+			continue
+		}
+		if c.Logs.Enabled() {
+			formatted, ok := c.Logs.Format(line)
+			if !ok {
+				continue
+			}
+			line = formatted
+		}
+		if prefix == "" {
+			fmt.Println(line)
+		} else {
+			fmt.Println(print.Blue(fmt.Sprintf("%s %s\n", prefix, line)))
+		}
+	}
+}
+
+type ExitError struct {
+	Name string
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("%s exited with status %d", e.Name, e.Code)
+}
+
+func StandaloneRun(ctx context.Context, config *StandaloneRunConfig) error {
 	if err := config.Default(); err != nil {
-		print.FailureStatusEvent(os.Stdout, err.Error())
-		return
+		return err
 	}
 
-	output, err := standalone.Run(&config.RunConfig)
-	if err != nil {
-		print.FailureStatusEvent(os.Stdout, err.Error())
-		return
+	if config.Detach && !IsDetached() {
+		return StandaloneDetach(ctx, config)
 	}
 
-	sigCh := make(chan os.Signal, 1)
-	SetupShutdownNotify(sigCh)
+	if config.Debug.Enabled {
+		args, err := config.Debug.Command(config.Arguments, "127.0.0.1")
+		if err != nil {
+			return err
+		}
+		config.Arguments = args
+		print.InfoStatusEvent(os.Stdout, "Debugging app with Delve, attach a debugger to 127.0.0.1:%d", config.Debug.Port)
+	}
 
-	daprRunning := make(chan bool, 1)
-	appRunning := make(chan bool, 1)
+	output, err := standalone.Run(&config.RunConfig)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if config.DaprCMD != nil {
+		output.DaprCMD = config.DaprCMD(output.DaprCMD)
+	}
 
-	go func() {
-		print.InfoStatusEvent(
-			os.Stdout,
-			fmt.Sprintf(
-				"Starting Dapr with id %s. HTTP Port: %v. gRPC Port: %v",
-				output.AppID,
-				output.DaprHTTPPort,
-				output.DaprGRPCPort))
+	if state, err := LoadAppState(output.AppID); err == nil && state.PID != os.Getpid() && ProcessAlive(state.PID) {
+		return errors.Errorf("App %s is already running with pid %d", output.AppID, state.PID)
+	}
 
-		output.DaprCMD.Stdout = os.Stdout
-		output.DaprCMD.Stderr = os.Stderr
+	// Outputs always go to log files in the app's state directory. A detached
+	// run is supervised by a background kess, which skips the console.
+	state := NewAppState(config, output)
+	if err := os.MkdirAll(state.Dir(), 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	defer RemoveAppState(state.AppID)
 
-		err = output.DaprCMD.Start()
-		if err != nil {
-			print.FailureStatusEvent(os.Stdout, err.Error())
-			os.Exit(1)
-		}
-
-		if config.AppPort <= 0 {
-			// If app does not listen to port, we can check for Dapr's sidecar health before starting the app.
-			// Otherwise, it creates a deadlock.
-			sidecarUp := true
-			print.InfoStatusEvent(os.Stdout, "Checking if Dapr sidecar is listening on HTTP port %v", output.DaprHTTPPort)
-			err = utils.IsDaprListeningOnPort(output.DaprHTTPPort, time.Duration(config.AppWaitTimeoutInSeconds)*time.Second)
-			if err != nil {
-				sidecarUp = false
-				print.WarningStatusEvent(os.Stdout, "Dapr sidecar is not listening on HTTP port: %s", err.Error())
-			}
+	daprLog, err := config.openLogFile(state.DaprLogFile)
+	if err != nil {
+		return err
+	}
+	defer daprLog.Close()
 
-			print.InfoStatusEvent(os.Stdout, "Checking if Dapr sidecar is listening on GRPC port %v", output.DaprGRPCPort)
-			err = utils.IsDaprListeningOnPort(output.DaprGRPCPort, time.Duration(config.AppWaitTimeoutInSeconds)*time.Second)
-			if err != nil {
-				sidecarUp = false
-				print.WarningStatusEvent(os.Stdout, "Dapr sidecar is not listening on GRPC port: %s", err.Error())
-			}
+	sigCh := make(chan os.Signal, 1)
+	SetupShutdownNotify(sigCh)
 
-			if sidecarUp {
-				print.InfoStatusEvent(os.Stdout, "Dapr sidecar is up and running.")
-			} else {
-				print.WarningStatusEvent(os.Stdout, "Dapr sidecar might not be responding.")
-			}
-		}
+	daprExited := make(chan error, 1)
+	appExited := make(chan error, 1)
+	gracePeriod := time.Duration(config.ShutdownGracePeriodInSeconds) * time.Second
 
-		daprRunning <- true
-	}()
+	print.InfoStatusEvent(
+		os.Stdout,
+		fmt.Sprintf(
+			"Starting Dapr with id %s. HTTP Port: %v. gRPC Port: %v",
+			output.AppID,
+			output.DaprHTTPPort,
+			output.DaprGRPCPort))
 
-	<-daprRunning
+	if err := config.startProcess(output.DaprCMD, daprLog, "", daprExited); err != nil {
+		return err
+	}
+	daprCMD, appCMD := output.DaprCMD, output.AppCMD
 
-	go func() {
-		if output.AppCMD == nil {
-			appRunning <- true
-			return
+	stopDapr := func() {
+		if err := StopProcess(daprCMD, daprExited, gracePeriod); err != nil {
+			print.FailureStatusEvent(os.Stdout, fmt.Sprintf("Error exiting Dapr: %s", err))
+		} else {
+			print.SuccessStatusEvent(os.Stdout, "Exited Dapr successfully")
 		}
+	}
 
-		if config.AppPwd != "" {
-			output.AppCMD.Dir = config.AppPwd
+	if config.AppPort <= 0 {
+		// If app does not listen to port, we can check for Dapr's sidecar health before starting the app.
+		// Otherwise, it creates a deadlock.
+		sidecarUp := true
+		print.InfoStatusEvent(os.Stdout, "Checking if Dapr sidecar is listening on HTTP port %v", output.DaprHTTPPort)
+		err = utils.IsDaprListeningOnPort(output.DaprHTTPPort, time.Duration(config.AppWaitTimeoutInSeconds)*time.Second)
+		if err != nil {
+			sidecarUp = false
+			print.WarningStatusEvent(os.Stdout, "Dapr sidecar is not listening on HTTP port: %s", err.Error())
 		}
 
-		stdErrPipe, pipeErr := output.AppCMD.StderrPipe()
-		if pipeErr != nil {
-			print.FailureStatusEvent(os.Stdout, fmt.Sprintf("Error creating stderr for App: %s", err.Error()))
-			os.Exit(1)
+		print.InfoStatusEvent(os.Stdout, "Checking if Dapr sidecar is listening on GRPC port %v", output.DaprGRPCPort)
+		err = utils.IsDaprListeningOnPort(output.DaprGRPCPort, time.Duration(config.AppWaitTimeoutInSeconds)*time.Second)
+		if err != nil {
+			sidecarUp = false
+			print.WarningStatusEvent(os.Stdout, "Dapr sidecar is not listening on GRPC port: %s", err.Error())
 		}
 
-		stdOutPipe, pipeErr := output.AppCMD.StdoutPipe()
-		if pipeErr != nil {
-			print.FailureStatusEvent(os.Stdout, fmt.Sprintf("Error creating stdout for App: %s", err.Error()))
-			os.Exit(1)
+		if sidecarUp {
+			print.InfoStatusEvent(os.Stdout, "Dapr sidecar is up and running.")
+		} else {
+			print.WarningStatusEvent(os.Stdout, "Dapr sidecar might not be responding.")
 		}
+	}
 
-		errScanner := bufio.NewScanner(stdErrPipe)
-		outScanner := bufio.NewScanner(stdOutPipe)
-		go func() {
-			for errScanner.Scan() {
-				fmt.Println(print.Blue(fmt.Sprintf("== APP == %s\n", errScanner.Text())))
-			}
-		}()
-
-		go func() {
-			for outScanner.Scan() {
-				fmt.Println(print.Blue(fmt.Sprintf("== APP == %s\n", outScanner.Text())))
-			}
-		}()
+	var appLog io.Writer
+	if appCMD != nil {
+		if config.AppPwd != "" {
+			appCMD.Dir = config.AppPwd
+		}
 
-		err = output.AppCMD.Start()
+		f, err := config.openLogFile(state.AppLogFile)
 		if err != nil {
-			print.FailureStatusEvent(os.Stdout, err.Error())
-			os.Exit(1)
+			stopDapr()
+			return err
 		}
+		defer f.Close()
+		appLog = f
 
-		appRunning <- true
-	}()
-
-	<-appRunning
+		if err := config.startProcess(appCMD, appLog, "== APP ==", appExited); err != nil {
+			stopDapr()
+			return err
+		}
+	}
 
 	// Metadata API is only available if app has started listening to port, so wait for app to start before calling metadata API.
 	err = metadata.Put(output.DaprHTTPPort, "cliPID", strconv.Itoa(os.Getpid()))
@@ -160,7 +287,7 @@ func StandaloneRun(config *StandaloneRunConfig) {
 		print.WarningStatusEvent(os.Stdout, "Could not update sidecar metadata for cliPID: %s", err.Error())
 	}
 
-	if output.AppCMD != nil {
+	if appCMD != nil {
 		appCommand := strings.Join(config.Arguments, " ")
 		print.InfoStatusEvent(os.Stdout, fmt.Sprintf("Updating metadata for app command: %s", appCommand))
 		err = metadata.Put(output.DaprHTTPPort, "appCommand", appCommand)
@@ -173,22 +300,178 @@ func StandaloneRun(config *StandaloneRunConfig) {
 		print.SuccessStatusEvent(os.Stdout, "You're up and running! Dapr logs will appear here.\n")
 	}
 
-	<-sigCh
-	print.InfoStatusEvent(os.Stdout, "\nterminated signal received: shutting down")
+	state.DaprPID = daprCMD.Process.Pid
+	if appCMD != nil {
+		state.AppPID = appCMD.Process.Pid
+	}
+	if err := SaveAppState(state); err != nil {
+		print.WarningStatusEvent(os.Stdout, "Could not save state of app %s: %s", state.AppID, err.Error())
+	}
 
-	err = output.DaprCMD.Process.Kill()
-	if err != nil {
-		print.FailureStatusEvent(os.Stdout, fmt.Sprintf("Error exiting Dapr: %s", err))
-	} else {
-		print.SuccessStatusEvent(os.Stdout, "Exited Dapr successfully")
+	daprPolicy := RestartPolicy{Name: RestartNo}
+	if config.Restart.Dapr {
+		daprPolicy = config.Restart
+	}
+	daprTracker := &restartTracker{policy: &daprPolicy}
+	appTracker := &restartTracker{policy: &config.Restart}
+	daprTracker.started()
+	appTracker.started()
+
+	var exitErr *ExitError
+	var daprRestart, appRestart <-chan time.Time
+	daprRunning, appRunning := true, appCMD != nil
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			print.InfoStatusEvent(os.Stdout, "\ncontext canceled: shutting down")
+			break loop
+		case <-sigCh:
+			print.InfoStatusEvent(os.Stdout, "\nterminated signal received: shutting down")
+			break loop
+		case err := <-daprExited:
+			daprRunning = false
+			code := ExitCode(err)
+			if backoff, ok := daprTracker.exited(code); ok {
+				print.WarningStatusEvent(os.Stdout, "Dapr exited with status %d: restarting in %s (restart %d)", code, backoff, daprTracker.restarts)
+				daprRestart = time.After(backoff)
+				continue
+			}
+			if code != 0 {
+				exitErr = &ExitError{Name: "Dapr", Code: code}
+			}
+			print.WarningStatusEvent(os.Stdout, "Dapr exited with status %d: shutting down", code)
+			break loop
+		case err := <-appExited:
+			appRunning = false
+			code := ExitCode(err)
+			if backoff, ok := appTracker.exited(code); ok {
+				print.WarningStatusEvent(os.Stdout, "App exited with status %d: restarting in %s (restart %d)", code, backoff, appTracker.restarts)
+				appRestart = time.After(backoff)
+				continue
+			}
+			if code != 0 {
+				exitErr = &ExitError{Name: "App", Code: code}
+			}
+			print.WarningStatusEvent(os.Stdout, "App exited with status %d: shutting down", code)
+			break loop
+		case <-daprRestart:
+			daprRestart = nil
+			daprCMD = cloneCmd(daprCMD)
+			if err := config.startProcess(daprCMD, daprLog, "", daprExited); err != nil {
+				print.FailureStatusEvent(os.Stdout, fmt.Sprintf("Error restarting Dapr: %s", err))
+				exitErr = &ExitError{Name: "Dapr", Code: 1}
+				break loop
+			}
+			daprRunning = true
+			daprTracker.started()
+			state.DaprPID, state.DaprRestarts = daprCMD.Process.Pid, daprTracker.restarts
+			print.SuccessStatusEvent(os.Stdout, "Restarted Dapr with pid %d (restart %d)", state.DaprPID, state.DaprRestarts)
+			if err := SaveAppState(state); err != nil {
+				print.WarningStatusEvent(os.Stdout, "Could not save state of app %s: %s", state.AppID, err.Error())
+			}
+		case <-appRestart:
+			appRestart = nil
+			appCMD = cloneCmd(appCMD)
+			if err := config.startProcess(appCMD, appLog, "== APP ==", appExited); err != nil {
+				print.FailureStatusEvent(os.Stdout, fmt.Sprintf("Error restarting App: %s", err))
+				exitErr = &ExitError{Name: "App", Code: 1}
+				break loop
+			}
+			appRunning = true
+			appTracker.started()
+			state.AppPID, state.AppRestarts = appCMD.Process.Pid, appTracker.restarts
+			print.SuccessStatusEvent(os.Stdout, "Restarted App with pid %d (restart %d)", state.AppPID, state.AppRestarts)
+			if err := SaveAppState(state); err != nil {
+				print.WarningStatusEvent(os.Stdout, "Could not save state of app %s: %s", state.AppID, err.Error())
+			}
+		}
 	}
 
-	if output.AppCMD != nil {
-		err = output.AppCMD.Process.Kill()
-		if err != nil {
+	// Stop the app first so it can drain while Dapr is still serving it.
+	if appRunning {
+		if err := StopProcess(appCMD, appExited, gracePeriod); err != nil {
 			print.FailureStatusEvent(os.Stdout, fmt.Sprintf("Error exiting App: %s", err))
 		} else {
 			print.SuccessStatusEvent(os.Stdout, "Exited App successfully")
 		}
 	}
+
+	if daprRunning {
+		stopDapr()
+	}
+
+	if exitErr != nil {
+		return exitErr
+	}
+	return nil
+}
+
+func (c *StandaloneRunConfig) startProcess(cmd *exec.Cmd, file io.Writer, prefix string, exited chan<- error) error {
+	stdErrPipe, err := cmd.StderrPipe()
+	if err != nil {
+		return errors.Wrapf(err, "Error creating stderr for %s", cmd.Path)
+	}
+
+	stdOutPipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return errors.Wrapf(err, "Error creating stdout for %s", cmd.Path)
+	}
+
+	var scanners sync.WaitGroup
+	scanners.Add(2)
+	go c.scanLogs(&scanners, stdErrPipe, file, prefix)
+	go c.scanLogs(&scanners, stdOutPipe, file, prefix)
+
+	SetupProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return errors.WithStack(err)
+	}
+	go waitProcess(cmd, &scanners, exited)
+	return nil
+}
+
+// cloneCmd prepares a fresh command to start a process again, an exec.Cmd
+// can only be started once.
+func cloneCmd(cmd *exec.Cmd) *exec.Cmd {
+	clone := exec.Command(cmd.Path, cmd.Args[1:]...)
+	clone.Env = cmd.Env
+	clone.Dir = cmd.Dir
+	return clone
+}
+
+func waitProcess(cmd *exec.Cmd, scanners *sync.WaitGroup, exited chan<- error) {
+	// Wait closes the output pipes, so let the scanners drain them first.
+	scanners.Wait()
+	exited <- cmd.Wait()
+}
+
+// StopProcess asks the process group to terminate and kills it once the grace
+// period has passed.
+func StopProcess(cmd *exec.Cmd, exited <-chan error, gracePeriod time.Duration) error {
+	if err := TerminateProcess(cmd.Process); err != nil {
+		return err
+	}
+	select {
+	case <-exited:
+		return nil
+	case <-time.After(gracePeriod):
+		print.WarningStatusEvent(os.Stdout, "Process %d did not exit within %s, killing it", cmd.Process.Pid, gracePeriod)
+	}
+	if err := KillProcess(cmd.Process); err != nil {
+		return err
+	}
+	<-exited
+	return nil
+}
+
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() > 0 {
+		return exitErr.ExitCode()
+	}
+	return 1
 }
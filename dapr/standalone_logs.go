@@ -0,0 +1,286 @@
+package dapr
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/pkg/errors"
+)
+
+var (
+	logsPollInterval = 500 * time.Millisecond
+)
+
+type StandaloneLogsConfig struct {
+	AppID   string
+	Follow  bool
+	Tail    int
+	Sidecar bool
+	All     bool
+	Logs    LogPipeline
+}
+
+func (c *StandaloneLogsConfig) Default() error {
+	if err := c.Logs.Default(); err != nil {
+		return err
+	}
+	return nil
+}
+
+type standaloneLogSource struct {
+	Filename string
+	Prefix   string
+	Color    func(a ...interface{}) string
+}
+
+type standaloneLogLine struct {
+	Source standaloneLogSource
+	Text   string
+}
+
+func StandaloneLogs(ctx context.Context, config *StandaloneLogsConfig) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+
+	dir := DefaultAppStateDirPath(config.AppID)
+	if _, err := os.Stat(dir); err != nil {
+		if os.IsNotExist(err) {
+			return errors.Errorf("No logs found for app %s", config.AppID)
+		}
+		return errors.WithStack(err)
+	}
+
+	app := standaloneLogSource{Filename: filepath.Join(dir, DefaultAppLogFilename), Prefix: "app", Color: print.Blue}
+	sidecar := standaloneLogSource{Filename: filepath.Join(dir, DefaultDaprLogFilename), Prefix: "sidecar", Color: print.Yellow}
+
+	var sources []standaloneLogSource
+	switch {
+	case config.All:
+		sources = []standaloneLogSource{app, sidecar}
+	case config.Sidecar:
+		sources = []standaloneLogSource{sidecar}
+	default:
+		sources = []standaloneLogSource{app}
+	}
+
+	lines := make(chan standaloneLogLine)
+	errs := make(chan error, len(sources))
+
+	var wg sync.WaitGroup
+	for _, source := range sources {
+		wg.Add(1)
+		go func(source standaloneLogSource) {
+			defer wg.Done()
+			errs <- readLogFile(ctx, source, config, lines)
+		}(source)
+	}
+
+	go func() {
+		wg.Wait()
+		close(lines)
+		close(errs)
+	}()
+
+	prefixed := len(sources) > 1
+	for line := range lines {
+		text := line.Text
+		if config.Logs.Enabled() {
+			formatted, ok := config.Logs.Format(text)
+			if !ok {
+				continue
+			}
+			text = formatted
+		}
+		if prefixed && config.Logs.Output != LogOutputJSON {
+			text = fmt.Sprintf("%s %s", line.Source.Color(fmt.Sprintf("%-7s |", line.Source.Prefix)), text)
+		}
+		fmt.Fprintln(os.Stdout, text)
+	}
+
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readRotatedLogFiles(filename string) ([]string, error) {
+	count := 0
+	for {
+		if _, err := os.Stat(RotatedFilename(filename, count+1)); err != nil {
+			break
+		}
+		count++
+	}
+
+	existing := []string{}
+	for i := count; i >= 1; i-- {
+		buf, err := ioutil.ReadFile(RotatedFilename(filename, i))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.WithStack(err)
+		}
+		if len(buf) == 0 {
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimRight(string(buf), "\n"), "\n") {
+			existing = append(existing, strings.TrimRight(line, "\r"))
+		}
+	}
+	return existing, nil
+}
+
+func readLogFile(ctx context.Context, source standaloneLogSource, config *StandaloneLogsConfig, lines chan<- standaloneLogLine) error {
+	existing, err := readRotatedLogFiles(source.Filename)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(source.Filename)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return errors.WithStack(err)
+		}
+		// The file was just rotated away or removed, the rotated lines are
+		// all there is until it shows up again.
+		sendLogLines(source, config, existing, lines)
+		if !config.Follow {
+			return nil
+		}
+		if f, err = waitLogFile(ctx, source.Filename); f == nil || err != nil {
+			return err
+		}
+		return followLogFile(ctx, source, f, lines)
+	}
+
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadString('\n')
+		if err == io.EOF {
+			// A partial last line is picked up again when following.
+			if _, err := f.Seek(-int64(len(line)), io.SeekCurrent); err != nil {
+				f.Close()
+				return errors.WithStack(err)
+			}
+			break
+		}
+		if err != nil {
+			f.Close()
+			return errors.WithStack(err)
+		}
+		existing = append(existing, strings.TrimRight(line, "\r\n"))
+	}
+	sendLogLines(source, config, existing, lines)
+
+	if !config.Follow {
+		f.Close()
+		return nil
+	}
+	return followLogFile(ctx, source, f, lines)
+}
+
+// followLogFile sends the lines written to the file from its current offset
+// on until ctx is done, and closes it.
+func followLogFile(ctx context.Context, source standaloneLogSource, f *os.File, lines chan<- standaloneLogLine) error {
+	defer func() {
+		f.Close()
+	}()
+
+	reader := bufio.NewReader(f)
+	pending := ""
+	for {
+		line, err := reader.ReadString('\n')
+		pending += line
+		if err == nil {
+			lines <- standaloneLogLine{Source: source, Text: strings.TrimRight(pending, "\r\n")}
+			pending = ""
+			continue
+		}
+		if err != io.EOF {
+			return errors.WithStack(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(logsPollInterval):
+		}
+
+		// The file was rotated or truncated, start over from the new file.
+		reopen, err := logFileReplaced(f, source.Filename)
+		if err != nil {
+			return err
+		}
+		if reopen {
+			f.Close()
+			if f, err = os.Open(source.Filename); err != nil {
+				return errors.WithStack(err)
+			}
+			reader.Reset(f)
+			pending = ""
+		}
+	}
+}
+
+func sendLogLines(source standaloneLogSource, config *StandaloneLogsConfig, existing []string, lines chan<- standaloneLogLine) {
+	if config.Tail >= 0 && len(existing) > config.Tail {
+		existing = existing[len(existing)-config.Tail:]
+	}
+	for _, line := range existing {
+		lines <- standaloneLogLine{Source: source, Text: line}
+	}
+}
+
+// waitLogFile opens the file once it exists, or returns nil when ctx is done
+// first.
+func waitLogFile(ctx context.Context, filename string) (*os.File, error) {
+	for {
+		f, err := os.Open(filename)
+		if err == nil {
+			return f, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, errors.WithStack(err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, nil
+		case <-time.After(logsPollInterval):
+		}
+	}
+}
+
+func logFileReplaced(f *os.File, filename string) (bool, error) {
+	current, err := f.Stat()
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	latest, err := os.Stat(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errors.WithStack(err)
+	}
+	if !os.SameFile(current, latest) {
+		return true, nil
+	}
+	offset, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return latest.Size() < offset, nil
+}
@@ -0,0 +1,69 @@
+package dapr
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func collectLogLines(t *testing.T, config *StandaloneLogsConfig, filename string, write func()) []string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	lines := make(chan standaloneLogLine)
+	done := make(chan error, 1)
+	go func() {
+		done <- readLogFile(ctx, standaloneLogSource{Filename: filename}, config, lines)
+		close(lines)
+	}()
+
+	got := []string{}
+	for line := range lines {
+		got = append(got, line.Text)
+		if line.Text == "last" {
+			cancel()
+		}
+		if write != nil && len(got) == 2 {
+			write()
+		}
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("readLogFile() error = %v", err)
+	}
+	return got
+}
+
+func TestReadLogFile(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "app.log")
+	if err := ioutil.WriteFile(RotatedFilename(filename, 2), []byte("one\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(RotatedFilename(filename, 1), []byte("two\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The base file is missing right after a rotation.
+	got := collectLogLines(t, &StandaloneLogsConfig{Tail: -1}, filename, nil)
+	if want := []string{"one", "two"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("readLogFile() = %v, want %v", got, want)
+	}
+
+	got = collectLogLines(t, &StandaloneLogsConfig{Tail: 1}, filename, nil)
+	if want := []string{"two"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("readLogFile() with tail = %v, want %v", got, want)
+	}
+
+	// Following waits for the base file to show up again.
+	got = collectLogLines(t, &StandaloneLogsConfig{Tail: -1, Follow: true}, filename, func() {
+		if err := ioutil.WriteFile(filename, []byte("three\nlast\n"), 0644); err != nil {
+			t.Error(err)
+		}
+	})
+	if want := []string{"one", "two", "three", "last"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("readLogFile() following = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,107 @@
+package dapr
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestStandaloneRunConfigDefault(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      StandaloneRunConfig
+		wantErr     bool
+		wantTimeout int
+	}{
+		{
+			name:        "defaults",
+			config:      StandaloneRunConfig{},
+			wantTimeout: DefaultAppWaitTimeoutInSeconds,
+		},
+		{
+			name:        "custom timeout",
+			config:      StandaloneRunConfig{AppWaitTimeoutInSeconds: 5},
+			wantTimeout: 5,
+		},
+		{
+			name:        "debugging raises the timeout",
+			config:      StandaloneRunConfig{AppWaitTimeoutInSeconds: 5, Debug: DebugConfig{Enabled: true}},
+			wantTimeout: DefaultDebugAppWaitTimeoutInSeconds,
+		},
+		{
+			name:        "debugging keeps a longer timeout",
+			config:      StandaloneRunConfig{AppWaitTimeoutInSeconds: 1000, Debug: DebugConfig{Port: 4000}},
+			wantTimeout: 1000,
+		},
+		{
+			name:    "unknown restart policy",
+			config:  StandaloneRunConfig{Restart: RestartPolicy{Name: "sometimes"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown log output",
+			config:  StandaloneRunConfig{Logs: LogPipeline{Output: "yaml"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Default()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Default() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if tt.config.AppWaitTimeoutInSeconds != tt.wantTimeout {
+				t.Errorf("AppWaitTimeoutInSeconds = %d, want %d", tt.config.AppWaitTimeoutInSeconds, tt.wantTimeout)
+			}
+			if tt.config.ShutdownGracePeriodInSeconds != DefaultShutdownGracePeriodInSeconds {
+				t.Errorf("ShutdownGracePeriodInSeconds = %d, want %d", tt.config.ShutdownGracePeriodInSeconds, DefaultShutdownGracePeriodInSeconds)
+			}
+			if tt.config.Restart.Name == "" {
+				t.Errorf("Restart.Name is empty")
+			}
+		})
+	}
+}
+
+// StandaloneRun returns invalid configs as errors before starting anything.
+func TestStandaloneRunInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *StandaloneRunConfig
+	}{
+		{name: "unknown restart policy", config: &StandaloneRunConfig{Restart: RestartPolicy{Name: "sometimes"}}},
+		{name: "unknown log level", config: &StandaloneRunConfig{Logs: LogPipeline{Level: "verbose"}}},
+		{name: "debugging without a command", config: &StandaloneRunConfig{Debug: DebugConfig{Enabled: true}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := StandaloneRun(context.Background(), tt.config); err == nil {
+				t.Errorf("StandaloneRun() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestDashboardRunNotInstalled(t *testing.T) {
+	home := os.Getenv("HOME")
+	defer os.Setenv("HOME", home)
+	os.Setenv("HOME", t.TempDir())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := DashboardRun(ctx, &DashboardRunConfig{}); err == nil {
+		t.Errorf("DashboardRun() error = nil, want an error")
+	}
+}
+
+func TestExitError(t *testing.T) {
+	err := &ExitError{Name: "App", Code: 3}
+	if got, want := err.Error(), "App exited with status 3"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
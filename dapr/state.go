@@ -0,0 +1,98 @@
+package dapr
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dapr/cli/pkg/standalone"
+	"github.com/pkg/errors"
+)
+
+type AppState struct {
+	AppID        string    `json:"appId"`
+	PID          int       `json:"pid"`
+	DaprPID      int       `json:"daprPid"`
+	AppPID       int       `json:"appPid,omitempty"`
+	DaprRestarts int       `json:"daprRestarts,omitempty"`
+	AppRestarts  int       `json:"appRestarts,omitempty"`
+	AppPort      int       `json:"appPort,omitempty"`
+	DaprHTTPPort int       `json:"daprHttpPort"`
+	DaprGRPCPort int       `json:"daprGrpcPort"`
+	Command      []string  `json:"command,omitempty"`
+	LogFile      string    `json:"logFile"`
+	DaprLogFile  string    `json:"daprLogFile"`
+	AppLogFile   string    `json:"appLogFile"`
+	StartedAt    time.Time `json:"startedAt"`
+}
+
+func NewAppState(config *StandaloneRunConfig, output *standalone.RunOutput) *AppState {
+	dir := DefaultAppStateDirPath(output.AppID)
+	return &AppState{
+		AppID:        output.AppID,
+		PID:          os.Getpid(),
+		AppPort:      config.AppPort,
+		DaprHTTPPort: output.DaprHTTPPort,
+		DaprGRPCPort: output.DaprGRPCPort,
+		Command:      config.Arguments,
+		LogFile:      filepath.Join(dir, DefaultKessLogFilename),
+		DaprLogFile:  filepath.Join(dir, DefaultDaprLogFilename),
+		AppLogFile:   filepath.Join(dir, DefaultAppLogFilename),
+		StartedAt:    time.Now(),
+	}
+}
+
+func (s *AppState) Dir() string {
+	return DefaultAppStateDirPath(s.AppID)
+}
+
+func SaveAppState(state *AppState) error {
+	buf, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := os.MkdirAll(state.Dir(), 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(state.Dir(), DefaultAppStateFilename), buf, 0644); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func LoadAppState(appID string) (*AppState, error) {
+	buf, err := ioutil.ReadFile(filepath.Join(DefaultAppStateDirPath(appID), DefaultAppStateFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Errorf("App %s is not running", appID)
+		}
+		return nil, errors.WithStack(err)
+	}
+	state := &AppState{}
+	if err := json.Unmarshal(buf, state); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return state, nil
+}
+
+// RemoveAppState only removes the state file, log files are kept for
+// `kess logs` after the app stopped.
+func RemoveAppState(appID string) error {
+	if err := os.Remove(filepath.Join(DefaultAppStateDirPath(appID), DefaultAppStateFilename)); err != nil && !os.IsNotExist(err) {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func OpenLogFile(filename string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	f, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return f, nil
+}
@@ -0,0 +1,283 @@
+package dapr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/pkg/errors"
+)
+
+var (
+	DefaultStateStoreName       = "statestore"
+	DefaultStateKeySeparator    = "||"
+	DefaultStateBulkParallelism = 10
+)
+
+type StateConfig struct {
+	ClientConfig
+	StoreName     string
+	AppID         string
+	Raw           bool
+	RedisAddress  string
+	RedisPassword string
+}
+
+func (c *StateConfig) Default() error {
+	if c.StoreName == "" {
+		c.StoreName = DefaultStateStoreName
+	}
+	if c.RedisAddress == "" {
+		c.RedisAddress = DefaultRedisAddress
+	}
+	// A sidecar scopes keys by the id of its own app, so the state of an app
+	// goes through the sidecar of that app. Apps in containers have no local
+	// sidecar to go through, their state is read from Redis in raw mode.
+	if !c.Raw && c.AppID != "" {
+		if c.Via != "" && c.Via != c.AppID {
+			return errors.Errorf("The state of app %s can only be reached through its own sidecar, not through %s", c.AppID, c.Via)
+		}
+		if _, err := LoadAppState(c.AppID); err != nil {
+			print.InfoStatusEvent(os.Stderr, "App %s has no local sidecar, reading its state from Redis in raw mode", c.AppID)
+			c.Via = ""
+			c.Raw = true
+			return nil
+		}
+		c.Via = c.AppID
+	}
+	return nil
+}
+
+// rawKey scopes a key by app id the way Dapr does for the keys an app saves,
+// so the state of an app can be read from Redis without being that app.
+func (c *StateConfig) rawKey(key string) string {
+	if c.AppID == "" {
+		return key
+	}
+	return c.AppID + DefaultStateKeySeparator + key
+}
+
+type StateItem struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value,omitempty"`
+	ETag  string          `json:"etag,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+func StateGet(ctx context.Context, config *StateConfig, keys []string) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+	if config.Raw {
+		return stateRawGet(config, keys)
+	}
+
+	client, err := NewClient(&config.ClientConfig)
+	if err != nil {
+		return err
+	}
+
+	var items []StateItem
+	if len(keys) == 1 {
+		resp, err := client.Do(ctx, ClientRequest{
+			Method: http.MethodGet,
+			Path:   fmt.Sprintf("/v1.0/state/%s/%s", config.StoreName, keys[0]),
+		})
+		if err != nil {
+			return err
+		}
+		if err := resp.Err(); err != nil {
+			return err
+		}
+		items = append(items, StateItem{Key: keys[0], Value: stateValue(resp.Body), ETag: resp.Headers.Get("ETag")})
+	} else {
+		bulk := []struct {
+			Key   string          `json:"key"`
+			Data  json.RawMessage `json:"data"`
+			ETag  string          `json:"etag"`
+			Error string          `json:"error"`
+		}{}
+		if _, err := client.DoJSON(ctx, ClientRequest{
+			Method: http.MethodPost,
+			Path:   fmt.Sprintf("/v1.0/state/%s/bulk", config.StoreName),
+		}, map[string]interface{}{"keys": keys, "parallelism": DefaultStateBulkParallelism}, &bulk); err != nil {
+			return err
+		}
+		for _, item := range bulk {
+			items = append(items, StateItem{
+				Key:   item.Key,
+				Value: item.Data,
+				ETag:  item.ETag,
+				Error: item.Error,
+			})
+		}
+	}
+	return printStateItems(items, config.Output)
+}
+
+func StateSet(ctx context.Context, config *StateConfig, key string, value string, etag string) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+	if config.Raw {
+		return errors.New("Raw mode only reads state with get, writing the state of an app needs its local sidecar")
+	}
+
+	client, err := NewClient(&config.ClientConfig)
+	if err != nil {
+		return err
+	}
+
+	item := map[string]interface{}{
+		"key":   key,
+		"value": json.RawMessage(stateValue([]byte(value))),
+	}
+	if etag != "" {
+		item["etag"] = etag
+	}
+	if _, err := client.DoJSON(ctx, ClientRequest{
+		Method: http.MethodPost,
+		Path:   fmt.Sprintf("/v1.0/state/%s", config.StoreName),
+	}, []interface{}{item}, nil); err != nil {
+		return err
+	}
+	if config.Output != LogOutputJSON {
+		print.SuccessStatusEvent(os.Stdout, "Saved state %s to %s", key, config.StoreName)
+	}
+	return nil
+}
+
+func StateDelete(ctx context.Context, config *StateConfig, key string, etag string) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+	if config.Raw {
+		return errors.New("Raw mode only reads state with get, writing the state of an app needs its local sidecar")
+	}
+
+	client, err := NewClient(&config.ClientConfig)
+	if err != nil {
+		return err
+	}
+
+	headers := http.Header{}
+	if etag != "" {
+		headers.Set("If-Match", etag)
+	}
+	resp, err := client.Do(ctx, ClientRequest{
+		Method:  http.MethodDelete,
+		Path:    fmt.Sprintf("/v1.0/state/%s/%s", config.StoreName, key),
+		Headers: headers,
+	})
+	if err != nil {
+		return err
+	}
+	if err := resp.Err(); err != nil {
+		return err
+	}
+	if config.Output != LogOutputJSON {
+		print.SuccessStatusEvent(os.Stdout, "Deleted state %s from %s", key, config.StoreName)
+	}
+	return nil
+}
+
+// StateQuery uses the alpha query API, which needs a runtime and a state
+// store supporting it.
+func StateQuery(ctx context.Context, config *StateConfig, query []byte) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+	if config.Raw {
+		return errors.New("Raw mode only reads state with get, writing the state of an app needs its local sidecar")
+	}
+	if !json.Valid(query) {
+		return errors.New("The query must be JSON")
+	}
+
+	client, err := NewClient(&config.ClientConfig)
+	if err != nil {
+		return err
+	}
+
+	result := struct {
+		Results []struct {
+			Key   string          `json:"key"`
+			Data  json.RawMessage `json:"data"`
+			ETag  string          `json:"etag"`
+			Error string          `json:"error"`
+		} `json:"results"`
+	}{}
+	if _, err := client.DoJSON(ctx, ClientRequest{
+		Method: http.MethodPost,
+		Path:   fmt.Sprintf("/v1.0-alpha1/state/%s/query", config.StoreName),
+	}, json.RawMessage(query), &result); err != nil {
+		return err
+	}
+
+	items := []StateItem{}
+	for _, item := range result.Results {
+		items = append(items, StateItem{Key: item.Key, Value: item.Data, ETag: item.ETag, Error: item.Error})
+	}
+	return printStateItems(items, config.Output)
+}
+
+// stateRawGet reads the Redis hashes the state store writes, keys are glob
+// patterns here, e.g. myapp||MyActor||* for the state of all actors of a type.
+func stateRawGet(config *StateConfig, patterns []string) error {
+	conn, err := dialRedis(config.RedisAddress, config.RedisPassword)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	items := []StateItem{}
+	for _, pattern := range patterns {
+		keys, err := conn.scan(config.rawKey(pattern))
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			fields, err := conn.hgetall(key)
+			if err != nil {
+				items = append(items, StateItem{Key: key, Error: err.Error()})
+				continue
+			}
+			items = append(items, StateItem{Key: key, Value: stateValue([]byte(fields["data"])), ETag: fields["version"]})
+		}
+	}
+	return printStateItems(items, config.Output)
+}
+
+// stateValue keeps JSON values as they are and quotes anything else.
+func stateValue(buf []byte) json.RawMessage {
+	if len(buf) == 0 {
+		return nil
+	}
+	if json.Valid(buf) {
+		return buf
+	}
+	quoted, _ := json.Marshal(string(buf))
+	return quoted
+}
+
+func printStateItems(items []StateItem, output string) error {
+	if output == LogOutputJSON {
+		return printJSON(items)
+	}
+
+	for _, item := range items {
+		value := string(item.Value)
+		if item.Error != "" {
+			value = print.Red(item.Error)
+		}
+		etag := item.ETag
+		if etag == "" {
+			etag = "-"
+		}
+		fmt.Fprintf(os.Stdout, "%s\t%s\t%s\n", item.Key, etag, value)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package dapr
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestStateConfigDefault(t *testing.T) {
+	home := os.Getenv("HOME")
+	defer os.Setenv("HOME", home)
+	os.Setenv("HOME", t.TempDir())
+	if err := SaveAppState(&AppState{AppID: "myapp"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		config  StateConfig
+		via     string
+		raw     bool
+		wantErr bool
+	}{
+		{name: "ingress", config: StateConfig{}},
+		{name: "app through its sidecar", config: StateConfig{AppID: "myapp"}, via: "myapp"},
+		{name: "app through the same sidecar", config: StateConfig{AppID: "myapp", ClientConfig: ClientConfig{Via: "myapp"}}, via: "myapp"},
+		{name: "app through another sidecar", config: StateConfig{AppID: "myapp", ClientConfig: ClientConfig{Via: "other"}}, wantErr: true},
+		{name: "raw keeps the ingress", config: StateConfig{AppID: "myapp", Raw: true}, raw: true},
+		{name: "app without a local sidecar", config: StateConfig{AppID: "container"}, raw: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Default()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Default() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (tt.config.Via != tt.via || tt.config.Raw != tt.raw) {
+				t.Errorf("Via = %q, Raw = %v, want %q, %v", tt.config.Via, tt.config.Raw, tt.via, tt.raw)
+			}
+		})
+	}
+}
+
+func TestStateRawKey(t *testing.T) {
+	tests := []struct {
+		appID string
+		key   string
+		want  string
+	}{
+		{appID: "", key: "order", want: "order"},
+		{appID: "myapp", key: "order", want: "myapp||order"},
+		{appID: "myapp", key: "MyActor||*", want: "myapp||MyActor||*"},
+	}
+
+	for _, tt := range tests {
+		config := StateConfig{AppID: tt.appID, Raw: true}
+		if got := config.rawKey(tt.key); got != tt.want {
+			t.Errorf("rawKey(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestStateRawOnlyReads(t *testing.T) {
+	ctx := context.Background()
+	config := &StateConfig{Raw: true}
+	if err := StateSet(ctx, config, "key", "value", ""); err == nil {
+		t.Errorf("StateSet() error = nil in raw mode")
+	}
+	if err := StateDelete(ctx, config, "key", ""); err == nil {
+		t.Errorf("StateDelete() error = nil in raw mode")
+	}
+	if err := StateQuery(ctx, config, []byte(`{}`)); err == nil {
+		t.Errorf("StateQuery() error = nil in raw mode")
+	}
+}
@@ -0,0 +1,100 @@
+package dapr
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+	"github.com/pkg/errors"
+)
+
+var (
+	DefaultTempAppLogLevel = "warn"
+)
+
+// TempAppRunner runs the sidecar of a temporary app, StandaloneRun unless a
+// runtime puts the sidecar somewhere else.
+type TempAppRunner func(ctx context.Context, config *StandaloneRunConfig) error
+
+func tempAppID(tpl string) string {
+	return strings.ReplaceAll(tpl, "{Suffix}", strconv.FormatInt(time.Now().UnixNano(), 10))
+}
+
+// runTempApp serves handler as the app of a sidecar using the Dapr configs
+// kess installed, or the components in componentsPath if given, until ctx is
+// done or the run is interrupted. ready is called with the state of the app
+// once its sidecar is up.
+func runTempApp(ctx context.Context, run TempAppRunner, appID string, componentsPath string, handler http.Handler, ready func(state *AppState) error) error {
+	if run == nil {
+		run = StandaloneRun
+	}
+	if componentsPath == "" {
+		componentsPath = DefaultComponentsDirPath()
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	server := &http.Server{Handler: handler}
+	go server.Serve(listener)
+	defer server.Close()
+
+	runConfig := &StandaloneRunConfig{
+		RunConfig: standalone.RunConfig{
+			AppID:          appID,
+			AppPort:        listener.Addr().(*net.TCPAddr).Port,
+			HTTPPort:       DefaultRandomPort,
+			GRPCPort:       DefaultRandomPort,
+			ConfigFile:     DefaultConfigFilePath(),
+			Protocol:       "http",
+			ProfilePort:    DefaultRandomPort,
+			LogLevel:       DefaultTempAppLogLevel,
+			MaxConcurrency: DefaultRandomPort,
+			PlacementHost:  "localhost",
+			ComponentsPath: componentsPath,
+			MetricsPort:    DefaultRandomPort,
+		},
+		AppWaitTimeoutInSeconds:      DefaultAppWaitTimeoutInSeconds,
+		LogMaxSizeInMB:               DefaultLogMaxSizeInMB,
+		LogMaxFiles:                  DefaultLogMaxFiles,
+		ShutdownGracePeriodInSeconds: DefaultShutdownGracePeriodInSeconds,
+	}
+
+	if ready != nil {
+		readyCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			for {
+				if state, err := LoadAppState(appID); err == nil && state.PID == os.Getpid() {
+					if err := ready(state); err != nil {
+						print.FailureStatusEvent(os.Stdout, err.Error())
+					}
+					return
+				}
+				select {
+				case <-readyCtx.Done():
+					return
+				case <-time.After(logsPollInterval):
+				}
+			}
+		}()
+	}
+
+	// Only clean up a state dir this run created, the id may be the one of
+	// an app which is already running.
+	dir := DefaultAppStateDirPath(appID)
+	_, statErr := os.Stat(dir)
+	err = run(ctx, runConfig)
+	if os.IsNotExist(statErr) {
+		os.RemoveAll(dir)
+	}
+	return err
+}
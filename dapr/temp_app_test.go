@@ -0,0 +1,58 @@
+package dapr
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestTempAppID(t *testing.T) {
+	first, second := tempAppID(DefaultSubscribeAppID), tempAppID(DefaultSubscribeAppID)
+	if first == second {
+		t.Errorf("tempAppID() = %s twice", first)
+	}
+	if !strings.HasPrefix(first, "kess-subscribe-") || strings.Contains(first, "{Suffix}") {
+		t.Errorf("tempAppID() = %s", first)
+	}
+}
+
+func TestRunTempAppCleanup(t *testing.T) {
+	home := os.Getenv("HOME")
+	defer os.Setenv("HOME", home)
+	os.Setenv("HOME", t.TempDir())
+
+	// A run creating the state dir removes it again.
+	run := func(ctx context.Context, config *StandaloneRunConfig) error {
+		return os.MkdirAll(DefaultAppStateDirPath(config.AppID), 0755)
+	}
+	if err := runTempApp(context.Background(), run, "temp", "", http.NotFoundHandler(), nil); err != nil {
+		t.Fatalf("runTempApp() error = %v", err)
+	}
+	if _, err := os.Stat(DefaultAppStateDirPath("temp")); !os.IsNotExist(err) {
+		t.Errorf("runTempApp() kept the state dir of its app")
+	}
+
+	// The state dir of a running app with the same id is left alone.
+	running := filepath.Join(DefaultAppStateDirPath("running"), DefaultAppStateFilename)
+	if err := os.MkdirAll(filepath.Dir(running), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(running, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run = func(ctx context.Context, config *StandaloneRunConfig) error {
+		return errors.Errorf("App %s is already running", config.AppID)
+	}
+	if err := runTempApp(context.Background(), run, "running", "", http.NotFoundHandler(), nil); err == nil {
+		t.Fatalf("runTempApp() error = nil")
+	}
+	if _, err := os.Stat(running); err != nil {
+		t.Errorf("runTempApp() removed the state of a running app: %v", err)
+	}
+}
@@ -0,0 +1,238 @@
+package dapr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/pkg/errors"
+)
+
+var (
+	DefaultTracesSince    = 15 * time.Minute
+	DefaultTracesLimit    = 20
+	DefaultTracesBarWidth = 40
+)
+
+type TracesConfig struct {
+	ZipkinAddress string
+	Output        string
+}
+
+func (c *TracesConfig) Default() error {
+	if c.ZipkinAddress == "" {
+		c.ZipkinAddress = DefaultZipkinAddress
+	}
+	if c.Output == "" {
+		c.Output = LogOutputText
+	}
+	if c.Output != LogOutputText && c.Output != LogOutputJSON {
+		return errors.Errorf("Unknown output: %s", c.Output)
+	}
+	return nil
+}
+
+// Span is a span of the Zipkin v2 API, timestamps and durations are in
+// microseconds.
+type Span struct {
+	TraceID       string            `json:"traceId"`
+	ParentID      string            `json:"parentId,omitempty"`
+	ID            string            `json:"id"`
+	Kind          string            `json:"kind,omitempty"`
+	Name          string            `json:"name"`
+	Timestamp     int64             `json:"timestamp"`
+	Duration      int64             `json:"duration"`
+	LocalEndpoint *SpanEndpoint     `json:"localEndpoint,omitempty"`
+	Tags          map[string]string `json:"tags,omitempty"`
+}
+
+type SpanEndpoint struct {
+	ServiceName string `json:"serviceName"`
+}
+
+func (s *Span) Service() string {
+	if s.LocalEndpoint == nil {
+		return ""
+	}
+	return s.LocalEndpoint.ServiceName
+}
+
+type TracesListConfig struct {
+	TracesConfig
+	Service string
+	Since   time.Duration
+	Limit   int
+}
+
+func TracesList(ctx context.Context, config *TracesListConfig) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+	if config.Since <= 0 {
+		config.Since = DefaultTracesSince
+	}
+	if config.Limit <= 0 {
+		config.Limit = DefaultTracesLimit
+	}
+
+	query := url.Values{}
+	query.Set("endTs", strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10))
+	query.Set("lookback", strconv.FormatInt(int64(config.Since/time.Millisecond), 10))
+	query.Set("limit", strconv.Itoa(config.Limit))
+	if config.Service != "" {
+		query.Set("serviceName", config.Service)
+	}
+
+	traces := [][]Span{}
+	if err := getZipkin(ctx, &config.TracesConfig, "/api/v2/traces", query, &traces); err != nil {
+		return err
+	}
+
+	if config.Output == LogOutputJSON {
+		return printJSON(traces)
+	}
+	for _, spans := range traces {
+		if len(spans) == 0 {
+			continue
+		}
+		root := rootSpan(spans)
+		fmt.Fprintf(os.Stdout, "%s  %s  %-10s  %3d spans  %s %s\n",
+			root.TraceID,
+			time.Unix(0, root.Timestamp*int64(time.Microsecond)).Format(time.RFC3339),
+			time.Duration(root.Duration)*time.Microsecond,
+			len(spans),
+			print.Blue(root.Service()),
+			root.Name,
+		)
+	}
+	return nil
+}
+
+func TracesShow(ctx context.Context, config *TracesConfig, traceID string) error {
+	if err := config.Default(); err != nil {
+		return err
+	}
+
+	spans := []Span{}
+	if err := getZipkin(ctx, config, "/api/v2/trace/"+traceID, nil, &spans); err != nil {
+		return err
+	}
+
+	if config.Output == LogOutputJSON {
+		return printJSON(spans)
+	}
+	if len(spans) == 0 {
+		return errors.Errorf("Trace %s has no spans", traceID)
+	}
+
+	roots, children := spanTree(spans)
+	start, end := spanRange(spans)
+
+	fmt.Fprintf(os.Stdout, "Trace %s  %s\n", traceID, time.Duration(end-start)*time.Microsecond)
+	for _, root := range roots {
+		printSpanTree(root, children, "", start, end-start)
+	}
+	return nil
+}
+
+// spanTree returns the spans without a parent in the trace and the children
+// of every span by parent ID, all sorted by start. Spans whose parent is
+// missing from the trace count as roots.
+func spanTree(spans []Span) ([]Span, map[string][]Span) {
+	children := map[string][]Span{}
+	ids := map[string]bool{}
+	for _, span := range spans {
+		ids[span.ID] = true
+	}
+	roots := []Span{}
+	for _, span := range spans {
+		if span.ParentID == "" || !ids[span.ParentID] {
+			roots = append(roots, span)
+		} else {
+			children[span.ParentID] = append(children[span.ParentID], span)
+		}
+	}
+	sortSpans(roots)
+	for _, spans := range children {
+		sortSpans(spans)
+	}
+	return roots, children
+}
+
+// spanRange returns when the first span started and the last one ended.
+func spanRange(spans []Span) (int64, int64) {
+	start, end := spans[0].Timestamp, spans[0].Timestamp+spans[0].Duration
+	for _, span := range spans {
+		if span.Timestamp < start {
+			start = span.Timestamp
+		}
+		if span.Timestamp+span.Duration > end {
+			end = span.Timestamp + span.Duration
+		}
+	}
+	return start, end
+}
+
+// spanBar draws the offset and duration of the span as a bar relative to
+// the whole trace, at least one cell long.
+func spanBar(span Span, start int64, total int64, width int) string {
+	offset, length := 0, width
+	if total > 0 {
+		offset = int(float64(span.Timestamp-start) / float64(total) * float64(width))
+		length = int(float64(span.Duration) / float64(total) * float64(width))
+	}
+	if length < 1 {
+		length = 1
+	}
+	if offset+length > width {
+		offset = width - length
+	}
+	return strings.Repeat(" ", offset) + strings.Repeat("█", length) + strings.Repeat(" ", width-offset-length)
+}
+
+// printSpanTree draws one line per span with its offset and duration as a
+// bar relative to the whole trace.
+func printSpanTree(span Span, children map[string][]Span, indent string, start int64, total int64) {
+	bar := spanBar(span, start, total, DefaultTracesBarWidth)
+	label := fmt.Sprintf("%s%s %s", indent, span.Service(), span.Name)
+	fmt.Fprintf(os.Stdout, "%-60s |%s| %s\n", label, print.Blue(bar), time.Duration(span.Duration)*time.Microsecond)
+
+	for _, child := range children[span.ID] {
+		printSpanTree(child, children, indent+"  ", start, total)
+	}
+}
+
+func sortSpans(spans []Span) {
+	sort.Slice(spans, func(i, j int) bool { return spans[i].Timestamp < spans[j].Timestamp })
+}
+
+func rootSpan(spans []Span) Span {
+	root := spans[0]
+	for _, span := range spans {
+		if span.ParentID == "" {
+			return span
+		}
+		if span.Timestamp < root.Timestamp {
+			root = span
+		}
+	}
+	return root
+}
+
+func getZipkin(ctx context.Context, config *TracesConfig, path string, query url.Values, result interface{}) error {
+	client, err := NewClient(&ClientConfig{Address: config.ZipkinAddress, Output: config.Output})
+	if err != nil {
+		return err
+	}
+	if _, err := client.DoJSON(ctx, ClientRequest{Method: http.MethodGet, Path: path, Query: query}, nil, result); err != nil {
+		return errors.Wrap(err, "Error querying Zipkin")
+	}
+	return nil
+}
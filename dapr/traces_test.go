@@ -0,0 +1,146 @@
+package dapr
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func spanIDs(spans []Span) []string {
+	ids := []string{}
+	for _, span := range spans {
+		ids = append(ids, span.ID)
+	}
+	return ids
+}
+
+func TestSpanTree(t *testing.T) {
+	spans := []Span{
+		{ID: "c", ParentID: "a", Timestamp: 30},
+		{ID: "b", ParentID: "a", Timestamp: 20},
+		{ID: "a", Timestamp: 10},
+		{ID: "d", ParentID: "b", Timestamp: 25},
+		{ID: "e", ParentID: "missing", Timestamp: 5},
+	}
+
+	roots, children := spanTree(spans)
+	if got, want := spanIDs(roots), []string{"e", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("roots = %v, want %v", got, want)
+	}
+	tests := []struct {
+		parent   string
+		children []string
+	}{
+		{parent: "a", children: []string{"b", "c"}},
+		{parent: "b", children: []string{"d"}},
+		{parent: "c", children: []string{}},
+	}
+	for _, tt := range tests {
+		if got := spanIDs(children[tt.parent]); !reflect.DeepEqual(got, tt.children) {
+			t.Errorf("children[%s] = %v, want %v", tt.parent, got, tt.children)
+		}
+	}
+}
+
+func TestSpanRange(t *testing.T) {
+	spans := []Span{
+		{ID: "a", Timestamp: 100, Duration: 50},
+		{ID: "b", Timestamp: 90, Duration: 10},
+		{ID: "c", Timestamp: 120, Duration: 100},
+	}
+	start, end := spanRange(spans)
+	if start != 90 || end != 220 {
+		t.Errorf("spanRange() = %d, %d, want 90, 220", start, end)
+	}
+}
+
+func TestSpanBar(t *testing.T) {
+	tests := []struct {
+		name  string
+		span  Span
+		total int64
+		bar   string
+	}{
+		{name: "whole trace", span: Span{Timestamp: 0, Duration: 100}, total: 100, bar: "██████████"},
+		{name: "second half", span: Span{Timestamp: 50, Duration: 50}, total: 100, bar: "     █████"},
+		{name: "shorter than a cell", span: Span{Timestamp: 20, Duration: 1}, total: 100, bar: "  █       "},
+		{name: "at the end", span: Span{Timestamp: 100, Duration: 0}, total: 100, bar: "         █"},
+		{name: "empty trace", span: Span{}, total: 0, bar: "██████████"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if bar := spanBar(tt.span, 0, tt.total, 10); bar != tt.bar {
+				t.Errorf("spanBar() = %q, want %q", bar, tt.bar)
+			}
+		})
+	}
+}
+
+func TestRootSpan(t *testing.T) {
+	tests := []struct {
+		name  string
+		spans []Span
+		root  string
+	}{
+		{name: "without parent", spans: []Span{{ID: "b", ParentID: "a", Timestamp: 1}, {ID: "a", Timestamp: 2}}, root: "a"},
+		{name: "earliest when parents are missing", spans: []Span{{ID: "b", ParentID: "x", Timestamp: 2}, {ID: "c", ParentID: "x", Timestamp: 1}}, root: "c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if root := rootSpan(tt.spans); root.ID != tt.root {
+				t.Errorf("rootSpan() = %s, want %s", root.ID, tt.root)
+			}
+		})
+	}
+}
+
+func TestTracesShowJSON(t *testing.T) {
+	spans := []Span{
+		{TraceID: "t1", ID: "a", Name: "/invoke", Timestamp: 10, Duration: 20, LocalEndpoint: &SpanEndpoint{ServiceName: "myapp"}},
+		{TraceID: "t1", ParentID: "a", ID: "b", Name: "state/get", Timestamp: 15, Duration: 5},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v2/trace/t1" {
+			http.NotFound(w, req)
+			return
+		}
+		json.NewEncoder(w).Encode(spans)
+	}))
+	defer server.Close()
+
+	stdout := os.Stdout
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = writer
+	err = TracesShow(context.Background(), &TracesConfig{
+		ZipkinAddress: strings.TrimPrefix(server.URL, "http://"),
+		Output:        LogOutputJSON,
+	}, "t1")
+	writer.Close()
+	os.Stdout = stdout
+	if err != nil {
+		t.Fatalf("TracesShow() error = %v", err)
+	}
+
+	buf, err := ioutil.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []Span{}
+	if err := json.Unmarshal(buf, &got); err != nil {
+		t.Fatalf("TracesShow() printed %s, not JSON: %s", buf, err)
+	}
+	if !reflect.DeepEqual(got, spans) {
+		t.Errorf("TracesShow() = %+v, want %+v", got, spans)
+	}
+}
@@ -2,9 +2,8 @@ package runtimes
 
 import (
 	"context"
-	"fmt"
 	"io"
-	"os"
+	"net"
 	"strconv"
 	"strings"
 	"time"
@@ -22,12 +21,17 @@ import (
 )
 
 var (
-	DefaultDockerRuntimeNetwork = "kess"
-	DefaultDockerRuntimeVolumes = []string{"kess-configs"}
+	DefaultDockerRuntimeNetwork        = "kess"
+	DefaultDockerRuntimeRuntimeVersion = dapr.DefaultRuntimeVersion
+	DefaultDockerRuntimeVersionLabel   = "kess-runtime-version"
+	DefaultDockerRuntimeVolumes        = []string{"kess-configs"}
 
 	DefaultDockerRuntimeToolsName  = "kess-tools-{Suffix}"
 	DefaultDockerRuntimeToolsImage = "alpine:latest"
 	DefaultDockerRuntimeToolsCmd   = []string{"sleep", "infinity"}
+	// The debug image has curl, grpcurl and the usual network tools.
+	DefaultDockerRuntimeToolsDebugImage = "nicolaka/netshoot:latest"
+	DefaultDockerRuntimeToolsDebugCmd   = []string{"bash"}
 
 	DefaultDockerRuntimeRedisName         = "kess-system-redis"
 	DefaultDockerRuntimeRedisImage        = "redis:alpine"
@@ -47,15 +51,15 @@ var (
 	DefaultDockerRuntimeZipkinInternalHost = "kess-system-zipkin:9411"
 
 	DefaultDockerRuntimePlacementName         = "kess-system-placement"
-	DefaultDockerRuntimePlacementImage        = "daprio/dapr"
+	DefaultDockerRuntimePlacementImage        = "daprio/dapr:{RuntimeVersion}"
 	DefaultDockerRuntimePlacementCmd          = []string{"./placement"}
 	DefaultDockerRuntimePlacementNetwork      = DefaultDockerRuntimeNetwork
-	DefaultDockerRuntimePlacementPorts        = []string{"50005:50005"}
+	DefaultDockerRuntimePlacementPorts        = []string{"50005:50005", "50006:8080"}
 	DefaultDockerRuntimePlacementExternalHost = "localhost:50005"
 	DefaultDockerRuntimePlacementInternalHost = "kess-system-placement:50005"
 
 	DefaultDockerRuntimeIngressName  = "kess-system-ingress"
-	DefaultDockerRuntimeIngressImage = "daprio/daprd:edge"
+	DefaultDockerRuntimeIngressImage = "daprio/daprd:{RuntimeVersion}"
 	DefaultDockerRuntimeIngressCmd   = []string{
 		"./daprd",
 		"--placement-host-address", "kess-system-placement:50005",
@@ -70,7 +74,7 @@ var (
 	DefaultDockerRuntimeIngressVolumes = []string{"kess-configs:/kess-configs"}
 
 	DefaultDockerRuntimeSidecarName  = "kess-app-{AppID}-sidecar"
-	DefaultDockerRuntimeSidecarImage = "daprio/daprd:edge"
+	DefaultDockerRuntimeSidecarImage = "daprio/daprd:{RuntimeVersion}"
 	DefaultDockerRuntimeSidecarCmd   = []string{
 		"./daprd",
 		"--placement-host-address", "kess-system-placement:50005",
@@ -86,9 +90,11 @@ var (
 )
 
 type DockerRuntimeToolsConfig struct {
-	Name  string
-	Image string
-	Cmd   []string
+	Name       string
+	Image      string
+	Cmd        []string
+	DebugImage string
+	DebugCmd   []string
 }
 
 func (c *DockerRuntimeToolsConfig) Default() error {
@@ -101,6 +107,12 @@ func (c *DockerRuntimeToolsConfig) Default() error {
 	if len(c.Cmd) == 0 {
 		c.Cmd = DefaultDockerRuntimeToolsCmd
 	}
+	if c.DebugImage == "" {
+		c.DebugImage = DefaultDockerRuntimeToolsDebugImage
+	}
+	if len(c.DebugCmd) == 0 {
+		c.DebugCmd = DefaultDockerRuntimeToolsDebugCmd
+	}
 	return nil
 }
 
@@ -291,19 +303,37 @@ func (c *DockerRuntimeAppConfig) Default() error {
 }
 
 type DockerRuntimeConfig struct {
-	Debug     bool
-	Network   string
-	Volumes   []string
-	Tools     DockerRuntimeToolsConfig
-	Redis     DockerRuntimeRedisConfig
-	Zipkin    DockerRuntimeZipkinConfig
-	Placement DockerRuntimePlacementConfig
-	Ingress   DockerRuntimeIngressConfig
-	Sidecar   DockerRuntimeSidecarConfig
-	App       DockerRuntimeAppConfig
+	Debug            bool
+	Pull             string
+	RegistryAuth     string
+	RuntimeVersion   string
+	DashboardVersion string
+	Network          string
+	Volumes          []string
+	Tools            DockerRuntimeToolsConfig
+	Redis            DockerRuntimeRedisConfig
+	Tracing          string
+	Zipkin           DockerRuntimeZipkinConfig
+	Otel             DockerRuntimeOtelConfig
+	Metrics          bool
+	Prometheus       DockerRuntimePrometheusConfig
+	Placement        DockerRuntimePlacementConfig
+	Ingress          DockerRuntimeIngressConfig
+	Sidecar          DockerRuntimeSidecarConfig
+	App              DockerRuntimeAppConfig
+	Dashboard        DockerRuntimeDashboardConfig
 }
 
 func (c *DockerRuntimeConfig) Default() error {
+	if c.Pull == "" {
+		c.Pull = DefaultDockerRuntimePull
+	}
+	if c.RuntimeVersion == "" {
+		c.RuntimeVersion = DefaultDockerRuntimeRuntimeVersion
+	}
+	if c.DashboardVersion == "" {
+		c.DashboardVersion = DefaultDockerRuntimeDashboardVersion
+	}
 	if c.Network == "" {
 		c.Network = DefaultDockerRuntimeNetwork
 	}
@@ -316,9 +346,21 @@ func (c *DockerRuntimeConfig) Default() error {
 	if err := c.Redis.Default(); err != nil {
 		return err
 	}
+	if c.Tracing == "" {
+		c.Tracing = DefaultDockerRuntimeTracing
+	}
+	if err := validateTracing(c.Tracing); err != nil {
+		return err
+	}
 	if err := c.Zipkin.Default(); err != nil {
 		return err
 	}
+	if err := c.Otel.Default(); err != nil {
+		return err
+	}
+	if err := c.Prometheus.Default(); err != nil {
+		return err
+	}
 	if err := c.Placement.Default(); err != nil {
 		return err
 	}
@@ -331,6 +373,9 @@ func (c *DockerRuntimeConfig) Default() error {
 	if err := c.App.Default(); err != nil {
 		return err
 	}
+	if err := c.Dashboard.Default(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -358,14 +403,48 @@ func NewDockerRuntime(config DockerRuntimeConfig) (*DockerRuntime, error) {
 }
 
 func (r *DockerRuntime) Install(ctx context.Context, options RuntimeInstallOptions) error {
-	if err := dapr.StandaloneInstall(options.RuntimeVersion, options.DashboardVersion); err != nil {
-		return err
+	if options.Tracing != "" {
+		if err := validateTracing(options.Tracing); err != nil {
+			return err
+		}
+		r.config.Tracing = options.Tracing
+	}
+	if options.TracingExporter != "" {
+		r.config.Otel.Exporter = options.TracingExporter
+		if err := r.config.Otel.Default(); err != nil {
+			return err
+		}
+	}
+	if options.Metrics {
+		r.config.Metrics = true
+	}
+	if options.DashboardVersion != "" {
+		r.config.DashboardVersion = options.DashboardVersion
 	}
 
-	externalDaprConfigs := r.getDaprConfigs(r.config.Zipkin.ExternalHost, r.config.Redis.ExternalHost, r.config.Redis.Password)
+	if options.Bundle != "" {
+		if err := r.BundleLoad(ctx, RuntimeBundleLoadOptions{File: options.Bundle}); err != nil {
+			return err
+		}
+		r.config.Pull = DockerRuntimePullNever
+	} else {
+		version, err := dapr.ResolveRuntimeVersion(options.RuntimeVersion)
+		if err != nil {
+			return err
+		}
+		r.config.RuntimeVersion = version
+		if err := dapr.StandaloneInstall(version, options.DashboardVersion); err != nil {
+			return err
+		}
+	}
+
+	externalDaprConfigs := r.getDaprConfigs(r.getTracing(true), r.config.Redis.ExternalHost, r.config.Redis.Password, dapr.DefaultSecretsFilePath())
 	if err := externalDaprConfigs.Save(); err != nil {
 		return err
 	}
+	if err := dapr.EnsureLocalSecrets(dapr.DefaultSecretsFilePath()); err != nil {
+		return err
+	}
 
 	for _, volume := range r.config.Volumes {
 		if err := r.createVolume(ctx, volume); err != nil {
@@ -376,7 +455,7 @@ func (r *DockerRuntime) Install(ctx context.Context, options RuntimeInstallOptio
 
 	configsVolume := r.findConfigsVolume(r.config.Ingress.Volumes)
 	if configsVolume != "" {
-		internalDaprConfigs := r.getDaprConfigs(r.config.Zipkin.InternalHost, r.config.Redis.InternalHost, r.config.Redis.Password)
+		internalDaprConfigs := r.getDaprConfigs(r.getTracing(false), r.config.Redis.InternalHost, r.config.Redis.Password, r.secretsFile(configsVolume))
 		buf, err := internalDaprConfigs.Buffer()
 		if err != nil {
 			return err
@@ -384,6 +463,9 @@ func (r *DockerRuntime) Install(ctx context.Context, options RuntimeInstallOptio
 		if err := r.copyToVolume(ctx, configsVolume, buf); err != nil {
 			return err
 		}
+		if err := r.ensureVolumeSecrets(ctx, configsVolume); err != nil {
+			return err
+		}
 	}
 
 	if err := r.createNetwork(ctx, r.config.Network); err != nil {
@@ -403,27 +485,23 @@ func (r *DockerRuntime) Install(ctx context.Context, options RuntimeInstallOptio
 		return err
 	}
 
-	if err := r.runContainer(ctx, DockerRuntimeRunContainerOptions{
-		Name:    r.config.Zipkin.Name,
-		Image:   r.config.Zipkin.Image,
-		Cmd:     r.config.Zipkin.Cmd,
-		Network: r.config.Zipkin.Network,
-		Ports:   r.config.Zipkin.Ports,
-		Labels: r.labels(map[string]string{
-			"kess-system": "zipkin",
-		}),
-	}); err != nil {
+	if err := r.runTracing(ctx); err != nil {
+		return err
+	}
+
+	if err := r.runMetrics(ctx); err != nil {
 		return err
 	}
 
 	if err := r.runContainer(ctx, DockerRuntimeRunContainerOptions{
 		Name:    r.config.Placement.Name,
-		Image:   r.config.Placement.Image,
+		Image:   r.image(r.config.Placement.Image),
 		Cmd:     r.config.Placement.Cmd,
 		Network: r.config.Placement.Network,
 		Ports:   r.config.Placement.Ports,
 		Labels: r.labels(map[string]string{
-			"kess-system": "placement",
+			"kess-system":                    "placement",
+			DefaultDockerRuntimeVersionLabel: r.config.RuntimeVersion,
 		}),
 	}); err != nil {
 		return err
@@ -431,18 +509,25 @@ func (r *DockerRuntime) Install(ctx context.Context, options RuntimeInstallOptio
 
 	if err := r.runContainer(ctx, DockerRuntimeRunContainerOptions{
 		Name:    r.config.Ingress.Name,
-		Image:   r.config.Ingress.Image,
+		Image:   r.image(r.config.Ingress.Image),
 		Cmd:     r.config.Ingress.Cmd,
 		Network: r.config.Ingress.Network,
 		Ports:   r.config.Ingress.Ports,
 		Volumes: r.config.Ingress.Volumes,
 		Labels: r.labels(map[string]string{
-			"kess-system": "ingress",
+			"kess-system":                    "ingress",
+			DefaultDockerRuntimeVersionLabel: r.config.RuntimeVersion,
 		}),
 	}); err != nil {
 		return err
 	}
 
+	// The dashboard port is only published once kess docker dashboard asks
+	// for one.
+	if err := r.runDashboard(ctx, r.dashboardImage(), nil); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -491,6 +576,9 @@ func (r *DockerRuntime) Uninstall(ctx context.Context, options RuntimeUninstallO
 
 func (r *DockerRuntime) Run(ctx context.Context, options RuntimeRunOptions) error {
 	if options.AppImage == "" {
+		if options.Hybrid {
+			return r.runHybrid(ctx, options)
+		}
 		return r.runProcess(ctx, options)
 	}
 	return r.runDocker(ctx, options)
@@ -510,25 +598,15 @@ func (r *DockerRuntime) Remove(ctx context.Context, options RuntimeRemoveOptions
 	return nil
 }
 
-func (r *DockerRuntime) Logs(ctx context.Context, options RuntimeLogsOptions) error {
-	m := structs.Map(options)
-
-	reader, err := r.client.ContainerLogs(ctx, r.renderName(r.config.App.Name, m), types.ContainerLogsOptions{
-		ShowStderr: true,
-		ShowStdout: true,
-		Timestamps: false,
-		Follow:     options.Follow,
-		Tail:       options.Tail,
-	})
-	if err != nil {
-		return nil
+// restartApp restarts the app and then its sidecar, which shares the network
+// of the app container and has to join the new one.
+func (r *DockerRuntime) restartApp(ctx context.Context, appID string) error {
+	m := map[string]interface{}{"AppID": appID}
+	for _, name := range []string{r.renderName(r.config.App.Name, m), r.renderName(r.config.Sidecar.Name, m)} {
+		if err := r.client.ContainerRestart(ctx, name, nil); err != nil {
+			return errors.WithStack(err)
+		}
 	}
-	io.Copy(os.Stdout, reader)
-	return nil
-}
-
-func (r *DockerRuntime) Dashboard(ctx context.Context, options RuntimeDashboardOptions) error {
-	dapr.DashboardRun(&options.DashboardRunConfig)
 	return nil
 }
 
@@ -536,6 +614,10 @@ func (r *DockerRuntime) renderName(tpl string, m map[string]interface{}) string
 	return fasttemplate.New(tpl, "{", "}").ExecuteString(m)
 }
 
+func (r *DockerRuntime) image(tpl string) string {
+	return r.renderName(tpl, map[string]interface{}{"RuntimeVersion": r.config.RuntimeVersion})
+}
+
 func (r *DockerRuntime) runDocker(ctx context.Context, options RuntimeRunOptions) error {
 	m := map[string]interface{}{"AppID": options.AppID}
 
@@ -543,9 +625,12 @@ func (r *DockerRuntime) runDocker(ctx context.Context, options RuntimeRunOptions
 		return err
 	}
 
-	appContainerName := r.renderName(r.config.App.Name, m)
-	if err := r.runContainer(ctx, DockerRuntimeRunContainerOptions{
-		Name:    appContainerName,
+	if err := r.loadRuntimeVersion(ctx); err != nil {
+		return err
+	}
+
+	appContainerOptions := DockerRuntimeRunContainerOptions{
+		Name:    r.renderName(r.config.App.Name, m),
 		Image:   options.AppImage,
 		Cmd:     options.Arguments,
 		Network: r.config.App.Network,
@@ -553,19 +638,39 @@ func (r *DockerRuntime) runDocker(ctx context.Context, options RuntimeRunOptions
 		Labels: r.labels(map[string]string{
 			"kess-app": options.AppID,
 		}),
-	}); err != nil {
+	}
+	if err := options.Debug.Default(); err != nil {
+		return err
+	}
+	if options.Debug.Enabled {
+		if err := r.debugContainer(ctx, &appContainerOptions, options.Debug); err != nil {
+			return err
+		}
+	}
+	appContainerName := appContainerOptions.Name
+	if err := r.runContainer(ctx, appContainerOptions); err != nil {
 		return err
 	}
 
+	metricsPort := options.MetricsPort
+	if metricsPort <= 0 {
+		metricsPort = DefaultDockerRuntimeSidecarMetricsPort
+	}
 	if err := r.runContainer(ctx, DockerRuntimeRunContainerOptions{
-		Name:    r.renderName(r.config.Sidecar.Name, m),
-		Image:   r.config.Sidecar.Image,
-		Cmd:     append(r.config.Sidecar.Cmd, "--app-id", options.AppID, "--app-port", strconv.Itoa(options.AppPort)),
+		Name:  r.renderName(r.config.Sidecar.Name, m),
+		Image: r.image(r.config.Sidecar.Image),
+		Cmd: append(r.config.Sidecar.Cmd,
+			"--app-id", options.AppID,
+			"--app-port", strconv.Itoa(options.AppPort),
+			"--metrics-port", strconv.Itoa(metricsPort),
+		),
 		Network: r.renderName(r.config.Sidecar.Network, map[string]interface{}{"Container": appContainerName}),
 		Volumes: r.config.Sidecar.Volumes,
 		Labels: r.labels(map[string]string{
-			"kess-app":         options.AppID,
-			"kess-app-sidecar": options.AppID,
+			"kess-app":                              options.AppID,
+			"kess-app-sidecar":                      options.AppID,
+			DefaultDockerRuntimeSidecarMetricsLabel: net.JoinHostPort(appContainerName, strconv.Itoa(metricsPort)),
+			DefaultDockerRuntimeVersionLabel:        r.config.RuntimeVersion,
 		}),
 	}); err != nil {
 		return err
@@ -575,33 +680,27 @@ func (r *DockerRuntime) runDocker(ctx context.Context, options RuntimeRunOptions
 }
 
 func (r *DockerRuntime) runProcess(ctx context.Context, options RuntimeRunOptions) error {
-	dapr.StandaloneRun(&options.StandaloneRunConfig)
-	return nil
+	return dapr.StandaloneRun(ctx, &options.StandaloneRunConfig)
 }
 
 type DockerRuntimeRunContainerOptions struct {
-	Name    string
-	Image   string
-	Cmd     []string
-	Network string
-	Ports   []string
-	Volumes []string
-	Links   []string
-	Labels  map[string]string
+	Name        string
+	Image       string
+	Entrypoint  []string
+	Cmd         []string
+	Network     string
+	Ports       []string
+	Volumes     []string
+	Links       []string
+	User        string
+	CapAdd      []string
+	SecurityOpt []string
+	Labels      map[string]string
 }
 
 func (r *DockerRuntime) runContainer(ctx context.Context, options DockerRuntimeRunContainerOptions) error {
-	if _, _, err := r.client.ImageInspectWithRaw(ctx, options.Image); err != nil {
-		if !client.IsErrNotFound(err) {
-			return errors.WithStack(err)
-		}
-		reader, err := r.client.ImagePull(ctx, options.Image, types.ImagePullOptions{})
-		if err != nil {
-			return errors.WithStack(err)
-		}
-		if r.config.Debug {
-			io.Copy(os.Stdout, reader)
-		}
+	if err := r.ensureImage(ctx, options.Image); err != nil {
+		return err
 	}
 
 	exposedports, portbindings, err := nat.ParsePortSpecs(options.Ports)
@@ -611,13 +710,17 @@ func (r *DockerRuntime) runContainer(ctx context.Context, options DockerRuntimeR
 
 	resp, err := r.client.ContainerCreate(ctx, &container.Config{
 		Image:        options.Image,
+		Entrypoint:   options.Entrypoint,
 		Cmd:          options.Cmd,
 		ExposedPorts: exposedports,
+		User:         options.User,
 		Labels:       options.Labels,
 	}, &container.HostConfig{
 		NetworkMode:   container.NetworkMode(options.Network),
 		PortBindings:  portbindings,
 		Binds:         options.Volumes,
+		CapAdd:        options.CapAdd,
+		SecurityOpt:   options.SecurityOpt,
 		RestartPolicy: container.RestartPolicy{Name: "always"},
 	}, nil, nil, options.Name)
 	if err != nil {
@@ -679,16 +782,8 @@ func (r *DockerRuntime) removeNetwork(ctx context.Context, name string) error {
 func (r *DockerRuntime) copyToVolume(ctx context.Context, volume string, reader io.Reader) error {
 	dist := strings.SplitN(volume, ":", 2)[1]
 
-	containerName := r.renderName(r.config.Tools.Name, map[string]interface{}{"Suffix": strconv.FormatInt(time.Now().Unix(), 10)})
-	if err := r.runContainer(ctx, DockerRuntimeRunContainerOptions{
-		Name:    containerName,
-		Image:   r.config.Tools.Image,
-		Cmd:     r.config.Tools.Cmd,
-		Volumes: []string{volume},
-		Labels: r.labels(map[string]string{
-			"kess-tools": "",
-		}),
-	}); err != nil {
+	containerName, err := r.runTools(ctx, volume)
+	if err != nil {
 		return err
 	}
 
@@ -703,6 +798,22 @@ func (r *DockerRuntime) copyToVolume(ctx context.Context, volume string, reader
 	return nil
 }
 
+func (r *DockerRuntime) runTools(ctx context.Context, volume string) (string, error) {
+	containerName := r.renderName(r.config.Tools.Name, map[string]interface{}{"Suffix": strconv.FormatInt(time.Now().UnixNano(), 10)})
+	if err := r.runContainer(ctx, DockerRuntimeRunContainerOptions{
+		Name:    containerName,
+		Image:   r.config.Tools.Image,
+		Cmd:     r.config.Tools.Cmd,
+		Volumes: []string{volume},
+		Labels: r.labels(map[string]string{
+			"kess-tools": "",
+		}),
+	}); err != nil {
+		return "", err
+	}
+	return containerName, nil
+}
+
 func (r *DockerRuntime) labels(m map[string]string) map[string]string {
 	l := map[string]string{"kess": ""}
 	for k, v := range m {
@@ -720,15 +831,10 @@ func (r *DockerRuntime) findConfigsVolume(volumes []string) string {
 	return ""
 }
 
-func (r *DockerRuntime) getDaprConfigs(zipkinHost string, redisHost string, redisPassword string) *dapr.Configs {
+func (r *DockerRuntime) getDaprConfigs(tracing dapr.ConfigurationSpecTracing, redisHost string, redisPassword string, secretsFile string) *dapr.Configs {
 	daprConfigs := dapr.DefaultConfigs()
 	daprConfigs.SetConfiguration(dapr.CreateConfiguration("kess", dapr.ConfigurationSpec{
-		Tracing: dapr.ConfigurationSpecTracing{
-			SamplingRate: "1",
-			Zipkin: dapr.ConfigurationSpecTracingZipkin{
-				EndpointAddress: fmt.Sprintf("http://%s/api/v2/spans", zipkinHost),
-			},
-		},
+		Tracing: tracing,
 	}))
 	daprConfigs.SetComponents([]dapr.Component{
 		dapr.CreateRedisStateStoreComponent("statestore", dapr.RedisStateStoreComponentOptions{
@@ -740,6 +846,14 @@ func (r *DockerRuntime) getDaprConfigs(zipkinHost string, redisHost string, redi
 			Host:     redisHost,
 			Password: redisPassword,
 		}),
+		dapr.CreateRedisConfigurationComponent("configstore", dapr.RedisConfigurationComponentOptions{
+			Host:     redisHost,
+			Password: redisPassword,
+		}),
+		dapr.CreateLocalFileSecretStoreComponent("secretstore", dapr.LocalFileSecretStoreComponentOptions{
+			SecretsFile:     secretsFile,
+			NestedSeparator: dapr.DefaultSecretNestedSeparator,
+		}),
 	})
 	return daprConfigs
 }
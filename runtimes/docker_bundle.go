@@ -0,0 +1,208 @@
+package runtimes
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/pkg/errors"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	DefaultDockerRuntimeBundleFile        = "kess-bundle.tar"
+	DefaultDockerRuntimeBundleImagesFile  = "images.tar"
+	DefaultDockerRuntimeBundleVersionFile = "runtime-version"
+	DefaultDockerRuntimeBundleBinDirname  = "bin"
+	DefaultDockerRuntimeBundleTempPattern = "kess-images-*.tar"
+)
+
+func (r *DockerRuntime) BundleSave(ctx context.Context, options RuntimeBundleSaveOptions) error {
+	if options.RuntimeVersion == "" {
+		if err := r.loadRuntimeVersion(ctx); err != nil {
+			return err
+		}
+		options.RuntimeVersion = r.config.RuntimeVersion
+	}
+	version, err := dapr.ResolveRuntimeVersion(options.RuntimeVersion)
+	if err != nil {
+		return err
+	}
+	r.config.RuntimeVersion = version
+
+	images := r.images()
+	for _, image := range images {
+		if err := r.ensureImage(ctx, image); err != nil {
+			return err
+		}
+	}
+
+	imagesFile, err := ioutil.TempFile("", DefaultDockerRuntimeBundleTempPattern)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer os.Remove(imagesFile.Name())
+	defer imagesFile.Close()
+
+	print.InfoStatusEvent(os.Stdout, "Saving images: %s", strings.Join(images, ", "))
+	reader, err := r.client.ImageSave(ctx, images)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer reader.Close()
+	if _, err := io.Copy(imagesFile, reader); err != nil {
+		return errors.WithStack(err)
+	}
+
+	f, err := os.Create(options.File)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+	defer tw.Close()
+
+	if err := writeTarBytes(tw, DefaultDockerRuntimeBundleVersionFile, []byte(version)); err != nil {
+		return err
+	}
+
+	if err := writeTarFile(tw, DefaultDockerRuntimeBundleImagesFile, imagesFile.Name()); err != nil {
+		return err
+	}
+
+	binDir := dapr.DefaultDaprBinPath()
+	print.InfoStatusEvent(os.Stdout, "Saving Dapr binaries from %s", binDir)
+	if err := filepath.Walk(binDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(binDir, path)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		return writeTarFile(tw, filepath.ToSlash(filepath.Join(DefaultDockerRuntimeBundleBinDirname, rel)), path)
+	}); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	print.SuccessStatusEvent(os.Stdout, "Bundle saved to %s", options.File)
+	return nil
+}
+
+func (r *DockerRuntime) BundleLoad(ctx context.Context, options RuntimeBundleLoadOptions) error {
+	f, err := os.Open(options.File)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer f.Close()
+
+	binDir := dapr.DefaultDaprBinPath()
+	binPrefix := DefaultDockerRuntimeBundleBinDirname + "/"
+
+	tr := tar.NewReader(f)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		switch {
+		case header.Name == DefaultDockerRuntimeBundleVersionFile:
+			version, err := ioutil.ReadAll(tr)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			r.config.RuntimeVersion = strings.TrimSpace(string(version))
+		case header.Name == DefaultDockerRuntimeBundleImagesFile:
+			print.InfoStatusEvent(os.Stdout, "Loading images from %s", options.File)
+			resp, err := r.client.ImageLoad(ctx, tr, false)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			err = r.displayJSONMessages(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return errors.Wrap(err, "Failed to load images")
+			}
+		case strings.HasPrefix(header.Name, binPrefix) && header.Typeflag == tar.TypeReg:
+			path := filepath.Join(binDir, filepath.FromSlash(strings.TrimPrefix(header.Name, binPrefix)))
+			if !strings.HasPrefix(path, binDir+string(os.PathSeparator)) {
+				return errors.Errorf("Invalid bundle entry: %s", header.Name)
+			}
+			if err := readTarFile(tr, path, os.FileMode(header.Mode)); err != nil {
+				return err
+			}
+		}
+	}
+
+	print.SuccessStatusEvent(os.Stdout, "Bundle loaded from %s", options.File)
+	return nil
+}
+
+func writeTarFile(tw *tar.Writer, name string, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: int64(info.Mode().Perm()),
+		Size: info.Size(),
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := io.Copy(tw, f); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func writeTarBytes(tw *tar.Writer, name string, bytes []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: int64(len(bytes)),
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := tw.Write(bytes); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func readTarFile(tr *tar.Reader, path string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, tr); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
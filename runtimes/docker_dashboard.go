@@ -0,0 +1,134 @@
+package runtimes
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+	"github.com/pkg/errors"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	DefaultDockerRuntimeDashboardVersion = dapr.DefaultDashboardVersion
+
+	DefaultDockerRuntimeDashboardName  = "kess-system-dashboard"
+	DefaultDockerRuntimeDashboardImage = "daprio/dashboard:{DashboardVersion}"
+	DefaultDockerRuntimeDashboardCmd   = []string{
+		"--docker-compose=true",
+		"--components-path=/kess-configs/components",
+		"--config-path=/kess-configs/config.yaml",
+	}
+	DefaultDockerRuntimeDashboardNetwork = DefaultDockerRuntimeNetwork
+	DefaultDockerRuntimeDashboardPort    = 8080
+	DefaultDockerRuntimeDashboardVolumes = []string{"kess-configs:/kess-configs"}
+)
+
+type DockerRuntimeDashboardConfig struct {
+	Name    string
+	Image   string
+	Cmd     []string
+	Network string
+	Port    int
+	Volumes []string
+}
+
+func (c *DockerRuntimeDashboardConfig) Default() error {
+	if c.Name == "" {
+		c.Name = DefaultDockerRuntimeDashboardName
+	}
+	if c.Image == "" {
+		c.Image = DefaultDockerRuntimeDashboardImage
+	}
+	if len(c.Cmd) == 0 {
+		c.Cmd = DefaultDockerRuntimeDashboardCmd
+	}
+	if c.Network == "" {
+		c.Network = DefaultDockerRuntimeDashboardNetwork
+	}
+	if c.Port == 0 {
+		c.Port = DefaultDockerRuntimeDashboardPort
+	}
+	if len(c.Volumes) == 0 {
+		c.Volumes = DefaultDockerRuntimeDashboardVolumes
+	}
+	return nil
+}
+
+// Dashboard publishes the dashboard container on the local port and opens
+// it. The container keeps the image install or upgrade picked, it is only
+// recreated when the port changes.
+func (r *DockerRuntime) Dashboard(ctx context.Context, options RuntimeDashboardOptions) error {
+	if err := options.Default(); err != nil {
+		return err
+	}
+
+	image := r.dashboardImage()
+	info, err := r.client.ContainerInspect(ctx, r.config.Dashboard.Name)
+	if err == nil {
+		image = info.Config.Image
+	} else if !client.IsErrNotFound(err) {
+		return errors.WithStack(err)
+	}
+
+	port := strconv.Itoa(options.Port)
+	if err != nil || !r.dashboardPublished(info, port) {
+		if err := r.removeContainer(ctx, r.config.Dashboard.Name); err != nil {
+			return err
+		}
+		if err := r.runDashboard(ctx, image, []string{fmt.Sprintf("%s:%d", port, r.config.Dashboard.Port)}); err != nil {
+			return err
+		}
+	} else if !info.State.Running {
+		if err := r.client.ContainerStart(ctx, r.config.Dashboard.Name, types.ContainerStartOptions{}); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	url := fmt.Sprintf("http://localhost:%s", port)
+	print.SuccessStatusEvent(os.Stdout, "Dapr dashboard running on %s", url)
+	if err := dapr.OpenBrowser(url); err != nil {
+		print.WarningStatusEvent(os.Stdout, "Failed to open the browser: %s", err)
+	}
+	return nil
+}
+
+func (r *DockerRuntime) runDashboard(ctx context.Context, image string, ports []string) error {
+	if err := r.createNetwork(ctx, r.config.Dashboard.Network); err != nil {
+		return err
+	}
+	cmd := append([]string{fmt.Sprintf("--port=%d", r.config.Dashboard.Port)}, r.config.Dashboard.Cmd...)
+	return r.runContainer(ctx, DockerRuntimeRunContainerOptions{
+		Name:    r.config.Dashboard.Name,
+		Image:   image,
+		Cmd:     cmd,
+		Network: r.config.Dashboard.Network,
+		Ports:   ports,
+		Volumes: r.config.Dashboard.Volumes,
+		Labels: r.labels(map[string]string{
+			"kess-system": "dashboard",
+		}),
+	})
+}
+
+func (r *DockerRuntime) dashboardPublished(info types.ContainerJSON, port string) bool {
+	if info.HostConfig == nil {
+		return false
+	}
+	bindings := info.HostConfig.PortBindings[nat.Port(fmt.Sprintf("%d/tcp", r.config.Dashboard.Port))]
+	for _, binding := range bindings {
+		if binding.HostPort == port {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *DockerRuntime) dashboardImage() string {
+	return r.renderName(r.config.Dashboard.Image, map[string]interface{}{"DashboardVersion": r.config.DashboardVersion})
+}
@@ -0,0 +1,43 @@
+package runtimes
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/pkg/errors"
+	"github.com/yamajik/kess/dapr"
+)
+
+// debugContainer runs the command of the app image under Delve, which has
+// to be in the image, and publishes the debug port. Delve needs ptrace,
+// which the default seccomp profile of older engines blocks.
+func (r *DockerRuntime) debugContainer(ctx context.Context, options *DockerRuntimeRunContainerOptions, debug dapr.DebugConfig) error {
+	if err := r.ensureImage(ctx, options.Image); err != nil {
+		return err
+	}
+	info, _, err := r.client.ImageInspectWithRaw(ctx, options.Image)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	args := append([]string{}, info.Config.Entrypoint...)
+	if len(options.Cmd) > 0 {
+		args = append(args, options.Cmd...)
+	} else {
+		args = append(args, info.Config.Cmd...)
+	}
+	cmd, err := debug.Command(args, "0.0.0.0")
+	if err != nil {
+		return err
+	}
+
+	options.Entrypoint = cmd
+	options.Cmd = nil
+	options.Ports = append(options.Ports, fmt.Sprintf("%d:%d", debug.Port, debug.Port))
+	options.CapAdd = append(options.CapAdd, "SYS_PTRACE")
+	options.SecurityOpt = append(options.SecurityOpt, "seccomp=unconfined")
+	print.InfoStatusEvent(os.Stdout, "Debugging app with Delve, attach a debugger to localhost:%d", debug.Port)
+	return nil
+}
@@ -0,0 +1,237 @@
+package runtimes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/moby/term"
+	"github.com/pkg/errors"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	DefaultDockerRuntimeExecCmd = []string{"sh"}
+)
+
+// Exec runs a command in the app or sidecar container of the app, with a
+// TTY when stdin is a terminal.
+func (r *DockerRuntime) Exec(ctx context.Context, options RuntimeExecOptions) error {
+	m := map[string]interface{}{"AppID": options.AppID}
+	name := r.renderName(r.config.App.Name, m)
+	if options.Sidecar {
+		name = r.renderName(r.config.Sidecar.Name, m)
+	}
+	cmd := options.Cmd
+	if len(cmd) == 0 {
+		// The daprd image is distroless, there is no shell to default to.
+		if options.Sidecar {
+			return errors.Errorf("The sidecar image has no shell, give a command to run or use kess docker debug %s", options.AppID)
+		}
+		cmd = DefaultDockerRuntimeExecCmd
+	}
+
+	tty, restore, err := r.terminal(options.Tty)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	return r.exec(ctx, dockerExecOptions{
+		Container: name,
+		Cmd:       cmd,
+		Tty:       tty,
+		Stdin:     os.Stdin,
+		Stdout:    os.Stdout,
+		Stderr:    os.Stderr,
+	})
+}
+
+// Debug runs a tools container in the network and PID namespace of the app
+// container, so localhost reaches both the app and its sidecar.
+func (r *DockerRuntime) Debug(ctx context.Context, options RuntimeDebugOptions) error {
+	m := map[string]interface{}{"AppID": options.AppID}
+	appContainerName := r.renderName(r.config.App.Name, m)
+	if _, err := r.client.ContainerInspect(ctx, appContainerName); err != nil {
+		return errors.Wrapf(err, "App %s not found", options.AppID)
+	}
+
+	image := r.config.Tools.DebugImage
+	if err := r.ensureImage(ctx, image); err != nil {
+		return err
+	}
+	cmd := options.Cmd
+	if len(cmd) == 0 {
+		cmd = r.config.Tools.DebugCmd
+	}
+
+	print.InfoStatusEvent(os.Stdout, "Debugging %s, the sidecar listens on localhost:3500 (HTTP) and localhost:50001 (gRPC)%s", options.AppID, r.appPortHint(ctx, options.AppID))
+
+	tty, restore, err := r.terminal(options.Tty)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	name := r.renderName(r.config.Tools.Name, map[string]interface{}{"Suffix": strconv.FormatInt(time.Now().UnixNano(), 10)})
+	resp, err := r.client.ContainerCreate(ctx, &container.Config{
+		Image:        image,
+		Cmd:          cmd,
+		Tty:          tty,
+		OpenStdin:    true,
+		StdinOnce:    true,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Labels: r.labels(map[string]string{
+			"kess-tools": options.AppID,
+		}),
+	}, &container.HostConfig{
+		NetworkMode: container.NetworkMode("container:" + appContainerName),
+		PidMode:     container.PidMode("container:" + appContainerName),
+		Binds:       r.config.Sidecar.Volumes,
+		AutoRemove:  true,
+	}, nil, nil, name)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	hijacked, err := r.client.ContainerAttach(ctx, resp.ID, types.ContainerAttachOptions{
+		Stream: true,
+		Stdin:  true,
+		Stdout: true,
+		Stderr: true,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer hijacked.Close()
+
+	statusCh, errCh := r.client.ContainerWait(ctx, resp.ID, container.WaitConditionRemoved)
+	if err := r.client.ContainerStart(ctx, resp.ID, types.ContainerStartOptions{}); err != nil {
+		r.removeContainer(ctx, resp.ID)
+		return errors.WithStack(err)
+	}
+	if tty {
+		if ws, err := term.GetWinsize(os.Stdout.Fd()); err == nil {
+			r.client.ContainerResize(ctx, resp.ID, types.ResizeOptions{Height: uint(ws.Height), Width: uint(ws.Width)})
+		}
+	}
+
+	go func() {
+		io.Copy(hijacked.Conn, os.Stdin)
+		hijacked.CloseWrite()
+	}()
+	if tty {
+		io.Copy(os.Stdout, hijacked.Reader)
+	} else {
+		stdcopy.StdCopy(os.Stdout, os.Stderr, hijacked.Reader)
+	}
+
+	select {
+	case status := <-statusCh:
+		if status.StatusCode != 0 {
+			return &dapr.ExitError{Name: cmd[0], Code: int(status.StatusCode)}
+		}
+		return nil
+	case err := <-errCh:
+		return errors.WithStack(err)
+	}
+}
+
+// appPortHint tells where the app listens, from the app port the sidecar
+// was started with.
+func (r *DockerRuntime) appPortHint(ctx context.Context, appID string) string {
+	info, err := r.client.ContainerInspect(ctx, r.renderName(r.config.Sidecar.Name, map[string]interface{}{"AppID": appID}))
+	if err != nil {
+		return ""
+	}
+	for i, arg := range info.Args {
+		if arg == "--app-port" && i+1 < len(info.Args) && info.Args[i+1] != "0" {
+			return fmt.Sprintf(", the app on localhost:%s", info.Args[i+1])
+		}
+	}
+	return ""
+}
+
+// terminal puts stdin in raw mode when a TTY is wanted and stdin is a
+// terminal, the returned func restores it.
+func (r *DockerRuntime) terminal(tty bool) (bool, func(), error) {
+	fd := os.Stdin.Fd()
+	if !tty || !term.IsTerminal(fd) {
+		return false, func() {}, nil
+	}
+	state, err := term.SetRawTerminal(fd)
+	if err != nil {
+		return false, nil, errors.WithStack(err)
+	}
+	return true, func() { term.RestoreTerminal(fd, state) }, nil
+}
+
+type dockerExecOptions struct {
+	Container string
+	Cmd       []string
+	Tty       bool
+	Stdin     io.Reader
+	Stdout    io.Writer
+	Stderr    io.Writer
+}
+
+// exec runs the command in the container through the exec API, returning an
+// ExitError when the command fails.
+func (r *DockerRuntime) exec(ctx context.Context, options dockerExecOptions) error {
+	resp, err := r.client.ContainerExecCreate(ctx, options.Container, types.ExecConfig{
+		Cmd:          options.Cmd,
+		Tty:          options.Tty,
+		AttachStdin:  options.Stdin != nil,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	hijacked, err := r.client.ContainerExecAttach(ctx, resp.ID, types.ExecStartCheck{Tty: options.Tty})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer hijacked.Close()
+
+	if options.Tty {
+		if ws, err := term.GetWinsize(os.Stdout.Fd()); err == nil {
+			r.client.ContainerExecResize(ctx, resp.ID, types.ResizeOptions{Height: uint(ws.Height), Width: uint(ws.Width)})
+		}
+	}
+
+	if options.Stdin != nil {
+		go func() {
+			io.Copy(hijacked.Conn, options.Stdin)
+			hijacked.CloseWrite()
+		}()
+	}
+
+	if options.Tty {
+		_, err = io.Copy(options.Stdout, hijacked.Reader)
+	} else {
+		_, err = stdcopy.StdCopy(options.Stdout, options.Stderr, hijacked.Reader)
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	info, err := r.client.ContainerExecInspect(ctx, resp.ID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if info.ExitCode != 0 {
+		return &dapr.ExitError{Name: options.Cmd[0], Code: info.ExitCode}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package runtimes
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	DefaultDockerRuntimeHybridDockerCLI = "docker"
+	DefaultDockerRuntimeHybridNetwork   = "host"
+	DefaultDockerRuntimeHybridDaprd     = "./daprd"
+	// The label tells which hybrid run created a sidecar, so a run only ever
+	// removes its own.
+	DefaultDockerRuntimeHybridLabel = "kess-hybrid"
+)
+
+// runHybrid runs the app as a host process with its sidecar in a container.
+// The sidecar shares the host network, so daprd reaches the app on localhost
+// while placement, Redis and Zipkin are reached through their published ports,
+// the same ones the external Dapr configs point to.
+//
+// Docker Desktop on macOS and Windows runs containers in a VM, where the host
+// network is the VM's and not the one the app listens on, so hybrid mode
+// fails there instead of starting a sidecar that cannot reach the app.
+//
+// The sidecar is named after the app, so an existing one means the app is
+// already running, unless it is an exited sidecar of an earlier hybrid run.
+func (r *DockerRuntime) runHybrid(ctx context.Context, options RuntimeRunOptions) error {
+	m := map[string]interface{}{"AppID": options.AppID}
+
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		return errors.Errorf("Hybrid mode needs host networking, which Docker Desktop on %s does not provide, run the app as an image or with kess run instead", runtime.GOOS)
+	}
+
+	if err := r.loadRuntimeVersion(ctx); err != nil {
+		return err
+	}
+
+	image := r.image(r.config.Sidecar.Image)
+	if err := r.ensureImage(ctx, image); err != nil {
+		return err
+	}
+
+	docker, err := exec.LookPath(DefaultDockerRuntimeHybridDockerCLI)
+	if err != nil {
+		return errors.Wrap(err, "Hybrid mode needs the docker CLI")
+	}
+
+	name := r.renderName(r.config.Sidecar.Name, m)
+	if info, err := r.client.ContainerInspect(ctx, name); err == nil {
+		if info.State.Running || info.Config.Labels[DefaultDockerRuntimeHybridLabel] == "" {
+			return errors.Errorf("App %s is already running", options.AppID)
+		}
+		// An exited sidecar of an earlier hybrid run, not removed yet.
+		if err := r.removeContainer(ctx, name); err != nil {
+			return err
+		}
+	} else if !client.IsErrNotFound(err) {
+		return errors.WithStack(err)
+	}
+	runID := strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	// The Dapr CLI appends the placement port itself.
+	placementHost, _, err := net.SplitHostPort(r.config.Placement.ExternalHost)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	options.PlacementHost = placementHost
+	options.DaprCMD = func(cmd *exec.Cmd) *exec.Cmd {
+		args := []string{"run", "--rm", "--name", name, "--network", DefaultDockerRuntimeHybridNetwork}
+		for k, v := range r.labels(map[string]string{
+			"kess-app":                       options.AppID,
+			"kess-app-sidecar":               options.AppID,
+			DefaultDockerRuntimeVersionLabel: r.config.RuntimeVersion,
+			DefaultDockerRuntimeHybridLabel:  runID,
+		}) {
+			args = append(args, "--label", fmt.Sprintf("%s=%s", k, v))
+		}
+		// Mount the components and configuration at the same paths, so the
+		// arguments built for the host daprd stay valid inside the container.
+		args = append(args, "--volume", fmt.Sprintf("%s:%s:ro", options.ComponentsPath, options.ComponentsPath))
+		if options.ConfigFile != "" {
+			args = append(args, "--volume", fmt.Sprintf("%s:%s:ro", options.ConfigFile, options.ConfigFile))
+		}
+		if _, err := os.Stat(dapr.DefaultSecretsFilePath()); err == nil {
+			args = append(args, "--volume", fmt.Sprintf("%s:%s:ro", dapr.DefaultSecretsFilePath(), dapr.DefaultSecretsFilePath()))
+		}
+		args = append(args, image, DefaultDockerRuntimeHybridDaprd)
+		args = append(args, cmd.Args[1:]...)
+		return exec.Command(docker, args...)
+	}
+
+	if !options.Detach || dapr.IsDetached() {
+		defer r.removeHybridSidecar(context.Background(), name, runID)
+	}
+	return dapr.StandaloneRun(ctx, &options.StandaloneRunConfig)
+}
+
+// removeHybridSidecar removes the sidecar only if the hybrid run created it,
+// another run of the app may own the name by now.
+func (r *DockerRuntime) removeHybridSidecar(ctx context.Context, name string, runID string) error {
+	info, err := r.client.ContainerInspect(ctx, name)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return nil
+		}
+		return errors.WithStack(err)
+	}
+	if info.Config.Labels[DefaultDockerRuntimeHybridLabel] != runID {
+		return nil
+	}
+	return r.removeContainer(ctx, name)
+}
+
+// runTempApp runs a temporary app kess serves itself as a hybrid app, so it
+// sees the same stack as containerised apps.
+func (r *DockerRuntime) runTempApp(ctx context.Context, config *dapr.StandaloneRunConfig) error {
+	return r.runHybrid(ctx, RuntimeRunOptions{StandaloneRunConfig: *config, Hybrid: true})
+}
+
+func (r *DockerRuntime) Subscribe(ctx context.Context, options RuntimeSubscribeOptions) error {
+	options.Runner = r.runTempApp
+	return dapr.Subscribe(ctx, &options.SubscribeConfig)
+}
+
+func (r *DockerRuntime) BindingListen(ctx context.Context, options RuntimeBindingListenOptions) error {
+	options.Runner = r.runTempApp
+	return dapr.BindingListen(ctx, &options.BindingListenConfig)
+}
@@ -0,0 +1,149 @@
+package runtimes
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	dockerconfig "github.com/docker/cli/cli/config"
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/moby/term"
+	"github.com/pkg/errors"
+)
+
+const (
+	DockerRuntimePullAlways  = "always"
+	DockerRuntimePullMissing = "missing"
+	DockerRuntimePullNever   = "never"
+)
+
+var (
+	DefaultDockerRuntimePull          = DockerRuntimePullMissing
+	DefaultDockerRuntimeIndexServer   = "https://index.docker.io/v1/"
+	DefaultDockerRuntimeIndexHostname = "docker.io"
+)
+
+func (r *DockerRuntime) ensureImage(ctx context.Context, image string) error {
+	switch r.config.Pull {
+	case DockerRuntimePullAlways:
+		return r.pullImage(ctx, image)
+	case DockerRuntimePullMissing, DockerRuntimePullNever:
+		if _, _, err := r.client.ImageInspectWithRaw(ctx, image); err != nil {
+			if !client.IsErrNotFound(err) {
+				return errors.WithStack(err)
+			}
+			if r.config.Pull == DockerRuntimePullNever {
+				return errors.Errorf("Image %s not found locally and pull policy is %s", image, r.config.Pull)
+			}
+			return r.pullImage(ctx, image)
+		}
+		return nil
+	default:
+		return errors.Errorf("Unknown pull policy: %s", r.config.Pull)
+	}
+}
+
+func (r *DockerRuntime) pullImage(ctx context.Context, image string) error {
+	auth, err := r.registryAuth(image)
+	if err != nil {
+		return err
+	}
+
+	reader, err := r.client.ImagePull(ctx, image, types.ImagePullOptions{RegistryAuth: auth})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer reader.Close()
+
+	if err := r.displayJSONMessages(reader); err != nil {
+		return errors.Wrapf(err, "Failed to pull image %s", image)
+	}
+	return nil
+}
+
+func (r *DockerRuntime) displayJSONMessages(reader io.Reader) error {
+	fd, isTerminal := term.GetFdInfo(os.Stdout)
+	return jsonmessage.DisplayJSONMessagesStream(reader, os.Stdout, fd, isTerminal, nil)
+}
+
+func (r *DockerRuntime) images() []string {
+	images := []string{}
+	seen := map[string]bool{}
+	for _, image := range []string{
+		r.config.Tools.Image,
+		r.config.Redis.Image,
+		r.config.Zipkin.Image,
+		r.config.Otel.Image,
+		r.config.Prometheus.Image,
+		r.image(r.config.Placement.Image),
+		r.image(r.config.Ingress.Image),
+		r.image(r.config.Sidecar.Image),
+		r.dashboardImage(),
+	} {
+		if !seen[image] {
+			seen[image] = true
+			images = append(images, image)
+		}
+	}
+	return images
+}
+
+func (r *DockerRuntime) registryAuth(image string) (string, error) {
+	if r.config.RegistryAuth != "" {
+		return encodeRegistryAuth(r.config.RegistryAuth)
+	}
+
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	hostname := reference.Domain(named)
+	if hostname == DefaultDockerRuntimeIndexHostname {
+		hostname = DefaultDockerRuntimeIndexServer
+	}
+
+	configFile := dockerconfig.LoadDefaultConfigFile(ioutil.Discard)
+	authConfig, err := configFile.GetAuthConfig(hostname)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if authConfig.Username == "" && authConfig.IdentityToken == "" && authConfig.RegistryToken == "" {
+		return "", nil
+	}
+
+	return encodeAuthConfig(types.AuthConfig{
+		Username:      authConfig.Username,
+		Password:      authConfig.Password,
+		ServerAddress: authConfig.ServerAddress,
+		IdentityToken: authConfig.IdentityToken,
+		RegistryToken: authConfig.RegistryToken,
+	})
+}
+
+// encodeRegistryAuth accepts either "username:password" or an already
+// encoded base64 auth config, as passed to --registry-auth.
+func encodeRegistryAuth(auth string) (string, error) {
+	parts := strings.SplitN(auth, ":", 2)
+	if len(parts) != 2 {
+		return auth, nil
+	}
+	return encodeAuthConfig(types.AuthConfig{
+		Username: parts[0],
+		Password: parts[1],
+	})
+}
+
+func encodeAuthConfig(authConfig types.AuthConfig) (string, error) {
+	buf, err := json.Marshal(authConfig)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return base64.URLEncoding.EncodeToString(buf), nil
+}
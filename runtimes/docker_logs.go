@@ -0,0 +1,207 @@
+package runtimes
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+	"github.com/yamajik/kess/dapr"
+)
+
+type dockerLogSource struct {
+	Name   string
+	Prefix string
+	Color  func(a ...interface{}) string
+}
+
+type dockerLogLine struct {
+	Source    dockerLogSource
+	Time      time.Time
+	Timestamp string
+	Text      string
+}
+
+func (r *DockerRuntime) Logs(ctx context.Context, options RuntimeLogsOptions) error {
+	if err := options.Pipeline.Default(); err != nil {
+		return err
+	}
+
+	m := map[string]interface{}{"AppID": options.AppID}
+
+	app := dockerLogSource{Name: r.renderName(r.config.App.Name, m), Prefix: "app", Color: print.Blue}
+	sidecar := dockerLogSource{Name: r.renderName(r.config.Sidecar.Name, m), Prefix: "sidecar", Color: print.Yellow}
+
+	switch {
+	case options.All:
+		return r.mergeLogs(ctx, []dockerLogSource{app, sidecar}, options)
+	case options.Sidecar:
+		return r.copyLogs(ctx, sidecar, options)
+	default:
+		return r.copyLogs(ctx, app, options)
+	}
+}
+
+func (r *DockerRuntime) containerLogs(ctx context.Context, name string, options RuntimeLogsOptions, timestamps bool) (io.ReadCloser, bool, error) {
+	info, err := r.client.ContainerInspect(ctx, name)
+	if err != nil {
+		return nil, false, errors.WithStack(err)
+	}
+
+	reader, err := r.client.ContainerLogs(ctx, name, types.ContainerLogsOptions{
+		ShowStderr: true,
+		ShowStdout: true,
+		Since:      options.Since,
+		Until:      options.Until,
+		Timestamps: timestamps,
+		Follow:     options.Follow,
+		Tail:       options.Tail,
+	})
+	if err != nil {
+		return nil, false, errors.WithStack(err)
+	}
+	return reader, info.Config.Tty, nil
+}
+
+func (r *DockerRuntime) copyLogs(ctx context.Context, source dockerLogSource, options RuntimeLogsOptions) error {
+	if options.Pipeline.Enabled() {
+		return r.mergeLogs(ctx, []dockerLogSource{source}, options)
+	}
+
+	reader, tty, err := r.containerLogs(ctx, source.Name, options, options.Timestamps)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	if tty {
+		_, err = io.Copy(os.Stdout, reader)
+	} else {
+		_, err = stdcopy.StdCopy(os.Stdout, os.Stderr, reader)
+	}
+	return errors.WithStack(err)
+}
+
+func (r *DockerRuntime) mergeLogs(ctx context.Context, sources []dockerLogSource, options RuntimeLogsOptions) error {
+	lines := make(chan dockerLogLine)
+	errs := make(chan error, len(sources))
+
+	var wg sync.WaitGroup
+	for _, source := range sources {
+		wg.Add(1)
+		go func(source dockerLogSource) {
+			defer wg.Done()
+			errs <- r.scanLogs(ctx, source, options, lines)
+		}(source)
+	}
+
+	go func() {
+		wg.Wait()
+		close(lines)
+		close(errs)
+	}()
+
+	// Without --follow all lines are known up front, so sort them to interleave
+	// the streams in the order they were written.
+	prefixed := len(sources) > 1
+	collected := []dockerLogLine{}
+	for line := range lines {
+		if options.Follow {
+			r.printLogLine(line, prefixed, options)
+		} else {
+			collected = append(collected, line)
+		}
+	}
+	sort.SliceStable(collected, func(i, j int) bool {
+		return collected[i].Time.Before(collected[j].Time)
+	})
+	for _, line := range collected {
+		r.printLogLine(line, prefixed, options)
+	}
+
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *DockerRuntime) scanLogs(ctx context.Context, source dockerLogSource, options RuntimeLogsOptions, lines chan<- dockerLogLine) error {
+	reader, tty, err := r.containerLogs(ctx, source.Name, options, true)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	pr, pw := io.Pipe()
+	go func() {
+		var err error
+		if tty {
+			_, err = io.Copy(pw, reader)
+		} else {
+			_, err = stdcopy.StdCopy(pw, pw, reader)
+		}
+		pw.CloseWithError(err)
+	}()
+
+	// Read whole lines however long they are, a scanner would stop the
+	// stream on a line over its token limit.
+	buffered := bufio.NewReader(pr)
+	for {
+		text, err := buffered.ReadString('\n')
+		if text == "" && err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return errors.WithStack(err)
+		}
+		parts := strings.SplitN(strings.TrimRight(text, "\r\n"), " ", 2)
+		line := dockerLogLine{Source: source, Timestamp: parts[0]}
+		if len(parts) == 2 {
+			line.Text = parts[1]
+		}
+		line.Time, _ = time.Parse(time.RFC3339Nano, line.Timestamp)
+		lines <- line
+	}
+}
+
+func (r *DockerRuntime) printLogLine(line dockerLogLine, prefixed bool, options RuntimeLogsOptions) {
+	text, ok := options.Pipeline.Format(line.Text)
+	if !ok {
+		return
+	}
+	if options.Pipeline.Output == dapr.LogOutputJSON {
+		// The source and the docker timestamp go into the object, daprd
+		// records keep the time they were logged at.
+		record := map[string]string{}
+		json.Unmarshal([]byte(text), &record)
+		record["source"] = line.Source.Prefix
+		if record["time"] == "" {
+			record["time"] = line.Timestamp
+		}
+		buf, _ := json.Marshal(record)
+		fmt.Fprintln(os.Stdout, string(buf))
+		return
+	}
+
+	parts := []string{}
+	if prefixed {
+		parts = append(parts, line.Source.Color(fmt.Sprintf("%-7s |", line.Source.Prefix)))
+	}
+	if options.Timestamps {
+		parts = append(parts, line.Timestamp)
+	}
+	parts = append(parts, text)
+	fmt.Fprintln(os.Stdout, strings.Join(parts, " "))
+}
@@ -0,0 +1,147 @@
+package runtimes
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	DefaultDockerRuntimeSidecarMetricsPort  = 9090
+	DefaultDockerRuntimeSidecarMetricsLabel = "kess-app-sidecar-metrics"
+
+	DefaultDockerRuntimePrometheusName         = "kess-system-prometheus"
+	DefaultDockerRuntimePrometheusImage        = "prom/prometheus:latest"
+	DefaultDockerRuntimePrometheusNetwork      = DefaultDockerRuntimeNetwork
+	DefaultDockerRuntimePrometheusPorts        = []string{"50009:9090"}
+	DefaultDockerRuntimePrometheusExternalHost = "localhost:50009"
+	DefaultDockerRuntimePrometheusVolumes      = []string{"kess-configs:/kess-configs", "/var/run/docker.sock:/var/run/docker.sock:ro"}
+	DefaultDockerRuntimePrometheusConfigFile   = "prometheus.yaml"
+	DefaultDockerRuntimePrometheusInterval     = "5s"
+	// Prometheus needs to read the docker socket to discover the sidecars.
+	DefaultDockerRuntimePrometheusUser = "0"
+)
+
+type DockerRuntimePrometheusConfig struct {
+	Name         string
+	Image        string
+	Network      string
+	Ports        []string
+	ExternalHost string
+	Volumes      []string
+	Interval     string
+}
+
+func (c *DockerRuntimePrometheusConfig) Default() error {
+	if c.Name == "" {
+		c.Name = DefaultDockerRuntimePrometheusName
+	}
+	if c.Image == "" {
+		c.Image = DefaultDockerRuntimePrometheusImage
+	}
+	if c.Network == "" {
+		c.Network = DefaultDockerRuntimePrometheusNetwork
+	}
+	if len(c.Ports) == 0 {
+		c.Ports = DefaultDockerRuntimePrometheusPorts
+	}
+	if c.ExternalHost == "" {
+		c.ExternalHost = DefaultDockerRuntimePrometheusExternalHost
+	}
+	if len(c.Volumes) == 0 {
+		c.Volumes = DefaultDockerRuntimePrometheusVolumes
+	}
+	if c.Interval == "" {
+		c.Interval = DefaultDockerRuntimePrometheusInterval
+	}
+	return nil
+}
+
+// runMetrics starts Prometheus when install asked for it, the scrape config
+// is written to the configs volume first.
+func (r *DockerRuntime) runMetrics(ctx context.Context) error {
+	if !r.config.Metrics {
+		return nil
+	}
+	configsVolume := r.findConfigsVolume(r.config.Prometheus.Volumes)
+	if configsVolume == "" {
+		return errors.New("Prometheus needs the configs volume")
+	}
+	buf, err := r.getPrometheusConfig()
+	if err != nil {
+		return err
+	}
+	if err := r.writeToVolume(ctx, configsVolume, DefaultDockerRuntimePrometheusConfigFile, buf); err != nil {
+		return err
+	}
+	return r.runContainer(ctx, DockerRuntimeRunContainerOptions{
+		Name:    r.config.Prometheus.Name,
+		Image:   r.config.Prometheus.Image,
+		Cmd:     []string{"--config.file", r.volumePath(configsVolume, DefaultDockerRuntimePrometheusConfigFile)},
+		Network: r.config.Prometheus.Network,
+		Ports:   r.config.Prometheus.Ports,
+		Volumes: r.config.Prometheus.Volumes,
+		User:    DefaultDockerRuntimePrometheusUser,
+		Labels: r.labels(map[string]string{
+			"kess-system": "prometheus",
+		}),
+	})
+}
+
+// getPrometheusConfig discovers the sidecars through the docker socket. The
+// sidecars share the network of their app container, so they carry the
+// address to scrape in a label instead of getting one from discovery.
+func (r *DockerRuntime) getPrometheusConfig() ([]byte, error) {
+	label := "__meta_docker_container_label_" + prometheusLabelName(DefaultDockerRuntimeSidecarMetricsLabel)
+	config := map[string]interface{}{
+		"global": map[string]interface{}{
+			"scrape_interval": r.config.Prometheus.Interval,
+		},
+		"scrape_configs": []interface{}{
+			map[string]interface{}{
+				"job_name": "kess-sidecars",
+				"docker_sd_configs": []interface{}{
+					map[string]interface{}{
+						"host":             "unix:///var/run/docker.sock",
+						"refresh_interval": r.config.Prometheus.Interval,
+						"filters": []interface{}{
+							map[string]interface{}{"name": "label", "values": []string{DefaultDockerRuntimeSidecarMetricsLabel}},
+						},
+					},
+				},
+				"relabel_configs": []interface{}{
+					map[string]interface{}{
+						"source_labels": []string{label},
+						"regex":         ".+",
+						"action":        "keep",
+					},
+					map[string]interface{}{
+						"source_labels": []string{label},
+						"target_label":  "__address__",
+					},
+					map[string]interface{}{
+						"source_labels": []string{"__meta_docker_container_name"},
+						"regex":         "/(.*)",
+						"target_label":  "container",
+					},
+				},
+			},
+		},
+	}
+	buf, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return buf, nil
+}
+
+func prometheusLabelName(name string) string {
+	buf := []byte(name)
+	for i, c := range buf {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			buf[i] = '_'
+		}
+	}
+	return string(buf)
+}
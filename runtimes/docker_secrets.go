@@ -0,0 +1,145 @@
+package runtimes
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
+	"github.com/yamajik/kess/dapr"
+)
+
+// SecretsSet writes the secret to the secrets file of the local file secret
+// store on the host and in the configs volume, then restarts the ingress
+// sidecar since the store only reads the file on init.
+func (r *DockerRuntime) SecretsSet(ctx context.Context, options RuntimeSecretsSetOptions) error {
+	secrets, err := dapr.LoadLocalSecrets(dapr.DefaultSecretsFilePath())
+	if err != nil {
+		return err
+	}
+	secrets[options.Key] = options.Value
+	if err := dapr.SaveLocalSecrets(dapr.DefaultSecretsFilePath(), secrets); err != nil {
+		return err
+	}
+
+	configsVolume := r.findConfigsVolume(r.config.Ingress.Volumes)
+	if configsVolume != "" {
+		buf, err := r.readFromVolume(ctx, configsVolume, dapr.DefaultSecretsFilename)
+		if err != nil {
+			return err
+		}
+		secrets := map[string]string{}
+		if err := dapr.UnmarshalLocalSecrets(buf, secrets); err != nil {
+			return err
+		}
+		secrets[options.Key] = options.Value
+		if err := r.writeVolumeSecrets(ctx, configsVolume, secrets); err != nil {
+			return err
+		}
+	}
+
+	if err := r.client.ContainerRestart(ctx, r.config.Ingress.Name, nil); err != nil && !client.IsErrNotFound(err) {
+		return errors.WithStack(err)
+	}
+	print.SuccessStatusEvent(os.Stdout, "Secret %s saved, restart running apps to pick it up", options.Key)
+	return nil
+}
+
+func (r *DockerRuntime) secretsFile(volume string) string {
+	return r.volumePath(volume, dapr.DefaultSecretsFilename)
+}
+
+func (r *DockerRuntime) ensureVolumeSecrets(ctx context.Context, volume string) error {
+	buf, err := r.readFromVolume(ctx, volume, dapr.DefaultSecretsFilename)
+	if err != nil || buf != nil {
+		return err
+	}
+	return r.writeVolumeSecrets(ctx, volume, map[string]string{})
+}
+
+func (r *DockerRuntime) writeVolumeSecrets(ctx context.Context, volume string, secrets map[string]string) error {
+	buf, err := dapr.MarshalLocalSecrets(secrets)
+	if err != nil {
+		return err
+	}
+	return r.writeToVolume(ctx, volume, dapr.DefaultSecretsFilename, buf)
+}
+
+// readFromVolume returns nil if the file does not exist in the volume.
+func (r *DockerRuntime) readFromVolume(ctx context.Context, volume string, name string) ([]byte, error) {
+	containerName, err := r.runTools(ctx, volume)
+	if err != nil {
+		return nil, err
+	}
+	defer r.removeContainer(ctx, containerName)
+
+	reader, _, err := r.client.CopyFromContainer(ctx, containerName, r.volumePath(volume, name))
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.WithStack(err)
+	}
+	defer reader.Close()
+
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if header.Typeflag == tar.TypeReg {
+			buf, err := ioutil.ReadAll(tr)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			return buf, nil
+		}
+	}
+}
+
+// readDirFromVolume returns the regular files in the directory of the volume
+// by name, or nothing if the directory does not exist.
+func (r *DockerRuntime) readDirFromVolume(ctx context.Context, volume string, name string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	containerName, err := r.runTools(ctx, volume)
+	if err != nil {
+		return nil, err
+	}
+	defer r.removeContainer(ctx, containerName)
+
+	reader, _, err := r.client.CopyFromContainer(ctx, containerName, r.volumePath(volume, name))
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return files, nil
+		}
+		return nil, errors.WithStack(err)
+	}
+	defer reader.Close()
+
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if header.Typeflag == tar.TypeReg {
+			buf, err := ioutil.ReadAll(tr)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			files[path.Base(header.Name)] = buf
+		}
+	}
+}
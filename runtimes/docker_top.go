@@ -0,0 +1,598 @@
+package runtimes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-units"
+	"github.com/moby/term"
+	"github.com/pkg/errors"
+)
+
+var (
+	DefaultDockerRuntimeTopInterval = time.Second
+	DefaultDockerRuntimeTopLogLines = 8
+	DefaultDockerRuntimeTopShell    = []string{"sh"}
+	// Sidecars are probed from a tools container on the host network, which
+	// reaches the sidecars of hybrid apps on localhost and the others on the
+	// address of their app container.
+	DefaultDockerRuntimeTopProbeNetwork = "host"
+	DefaultDockerRuntimeTopProbeTimeout = 2 * time.Second
+	DefaultDockerRuntimeTopDaprHTTPPort = "3500"
+	DefaultDockerRuntimeTopHealthzPath  = "/v1.0/healthz"
+)
+
+const (
+	dockerTopKindApp     = "app"
+	dockerTopKindSidecar = "sidecar"
+	dockerTopKindSystem  = "system"
+)
+
+type dockerTopRow struct {
+	ID       string
+	Name     string
+	Kind     string
+	AppID    string
+	State    string
+	Health   string
+	Restarts int
+}
+
+type dockerTopStats struct {
+	CPU      float64
+	Mem      uint64
+	MemLimit uint64
+	RxBytes  uint64
+	TxBytes  uint64
+}
+
+type dockerTop struct {
+	r       *DockerRuntime
+	options RuntimeTopOptions
+
+	mu      sync.Mutex
+	stats   map[string]*dockerTopStats
+	cancels map[string]context.CancelFunc
+	probe   string
+	healths map[string]string
+	probing map[string]bool
+
+	rows     []dockerTopRow
+	selected int
+	logs     []string
+	expanded bool
+	message  string
+	confirm  string
+}
+
+// Top shows the kess containers with live stats until q is pressed. The
+// terminal stays in raw mode the whole time, keys go to the exec session
+// while one is open.
+func (r *DockerRuntime) Top(ctx context.Context, options RuntimeTopOptions) error {
+	if options.Interval <= 0 {
+		options.Interval = DefaultDockerRuntimeTopInterval
+	}
+	if options.LogLines <= 0 {
+		options.LogLines = DefaultDockerRuntimeTopLogLines
+	}
+
+	fd := os.Stdin.Fd()
+	if !term.IsTerminal(fd) {
+		return errors.New("kess docker top needs a terminal")
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer term.RestoreTerminal(fd, state)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	t := &dockerTop{
+		r:       r,
+		options: options,
+		stats:   map[string]*dockerTopStats{},
+		cancels: map[string]context.CancelFunc{},
+		healths: map[string]string{},
+		probing: map[string]bool{},
+	}
+	if probe, err := r.runProbe(ctx); err != nil {
+		t.message = fmt.Sprintf("Sidecar health is unknown, failed to start the probe: %s", err)
+	} else {
+		t.probe = probe
+		defer r.removeContainer(context.Background(), probe)
+	}
+
+	keys := make(chan []byte)
+	go func() {
+		for {
+			buf := make([]byte, 16)
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				close(keys)
+				return
+			}
+			keys <- buf[:n]
+		}
+	}()
+
+	fmt.Fprint(os.Stdout, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(os.Stdout, "\x1b[?25h\x1b[?1049l")
+
+	ticker := time.NewTicker(options.Interval)
+	defer ticker.Stop()
+	for {
+		t.refresh(ctx)
+		t.draw()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			if quit := t.handleKey(ctx, key, keys); quit {
+				return nil
+			}
+		}
+	}
+}
+
+func (t *dockerTop) handleKey(ctx context.Context, key []byte, keys <-chan []byte) bool {
+	if t.confirm != "" {
+		appID := t.confirm
+		t.confirm = ""
+		t.message = ""
+		if string(key) == "y" {
+			if err := t.r.Remove(ctx, RuntimeRemoveOptions{AppID: appID}); err != nil {
+				t.message = err.Error()
+			} else {
+				t.message = fmt.Sprintf("Removed %s", appID)
+			}
+		}
+		return false
+	}
+
+	row := t.current()
+	switch string(key) {
+	case "q", "\x03":
+		return true
+	case "k", "\x1b[A":
+		if t.selected > 0 {
+			t.selected--
+		}
+	case "j", "\x1b[B":
+		if t.selected < len(t.rows)-1 {
+			t.selected++
+		}
+	case "l":
+		t.expanded = !t.expanded
+	case "r":
+		if row == nil {
+			break
+		}
+		t.message = fmt.Sprintf("Restarting %s", row.Name)
+		t.draw()
+		var err error
+		if row.AppID != "" {
+			err = t.r.restartApp(ctx, row.AppID)
+		} else {
+			err = errors.WithStack(t.r.client.ContainerRestart(ctx, row.ID, nil))
+		}
+		t.message = fmt.Sprintf("Restarted %s", row.Name)
+		if err != nil {
+			t.message = err.Error()
+		}
+	case "e":
+		if row != nil {
+			t.exec(ctx, row.Name, keys)
+		}
+	case "d":
+		if row != nil && row.AppID != "" {
+			t.confirm = row.AppID
+			t.message = fmt.Sprintf("Remove app %s? (y/n)", row.AppID)
+		}
+	}
+	return false
+}
+
+// exec opens a shell in the container on the main screen, feeding it the
+// keys until it exits.
+func (t *dockerTop) exec(ctx context.Context, name string, keys <-chan []byte) {
+	fmt.Fprint(os.Stdout, "\x1b[?25h\x1b[?1049l")
+	defer fmt.Fprint(os.Stdout, "\x1b[?1049h\x1b[?25l")
+
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- t.r.exec(ctx, dockerExecOptions{
+			Container: name,
+			Cmd:       DefaultDockerRuntimeTopShell,
+			Tty:       true,
+			Stdin:     pr,
+			Stdout:    os.Stdout,
+		})
+	}()
+	for {
+		select {
+		case key, ok := <-keys:
+			if ok {
+				pw.Write(key)
+			}
+		case err := <-done:
+			pw.Close()
+			t.message = ""
+			if err != nil {
+				t.message = err.Error()
+			}
+			return
+		}
+	}
+}
+
+func (t *dockerTop) current() *dockerTopRow {
+	if t.selected < 0 || t.selected >= len(t.rows) {
+		return nil
+	}
+	return &t.rows[t.selected]
+}
+
+func (t *dockerTop) refresh(ctx context.Context) {
+	containers, err := t.r.client.ContainerList(ctx, types.ContainerListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", "kess")),
+	})
+	if err != nil {
+		t.message = err.Error()
+		return
+	}
+
+	rows := []dockerTopRow{}
+	seen := map[string]bool{}
+	for _, c := range containers {
+		row := dockerTopRow{ID: c.ID, Name: strings.TrimPrefix(c.Names[0], "/"), State: c.State}
+		switch {
+		case c.Labels["kess-system"] != "":
+			row.Kind = dockerTopKindSystem + "/" + c.Labels["kess-system"]
+		case c.Labels["kess-app-sidecar"] != "":
+			row.Kind = dockerTopKindSidecar
+			row.AppID = c.Labels["kess-app-sidecar"]
+		case c.Labels["kess-app"] != "":
+			row.Kind = dockerTopKindApp
+			row.AppID = c.Labels["kess-app"]
+		default:
+			continue
+		}
+		if info, err := t.r.client.ContainerInspect(ctx, c.ID); err == nil {
+			row.Restarts = info.RestartCount
+			if info.State.Health != nil {
+				row.Health = info.State.Health.Status
+			} else if row.Kind == dockerTopKindSidecar && row.State == "running" {
+				t.probeSidecar(ctx, info)
+			}
+		}
+		row.Health = t.health(row)
+		rows = append(rows, row)
+		seen[c.ID] = true
+		if c.State == "running" {
+			t.watchStats(ctx, c.ID)
+		}
+	}
+
+	t.mu.Lock()
+	for id, cancel := range t.cancels {
+		if !seen[id] {
+			cancel()
+			delete(t.cancels, id)
+			delete(t.stats, id)
+		}
+	}
+	for id := range t.healths {
+		if !seen[id] {
+			delete(t.healths, id)
+		}
+	}
+	t.mu.Unlock()
+
+	// System containers first, then each app followed by its sidecar.
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].AppID != rows[j].AppID {
+			return rows[i].AppID < rows[j].AppID
+		}
+		return rows[i].Kind < rows[j].Kind
+	})
+
+	selectedID := ""
+	if row := t.current(); row != nil {
+		selectedID = row.ID
+	}
+	t.rows = rows
+	for i, row := range rows {
+		if row.ID == selectedID {
+			t.selected = i
+		}
+	}
+	if t.selected >= len(rows) {
+		t.selected = len(rows) - 1
+	}
+	if t.selected < 0 && len(rows) > 0 {
+		t.selected = 0
+	}
+
+	t.logs = nil
+	if row := t.current(); row != nil {
+		t.logs = t.tailLogs(ctx, row.ID)
+	}
+}
+
+// health shows the Docker health check of a container if it has one, the
+// last probe of daprd for a running sidecar, or else the container state.
+func (t *dockerTop) health(row dockerTopRow) string {
+	health := row.Health
+	if health == "" {
+		health = "down"
+		switch row.State {
+		case "running":
+			health = "up"
+			if row.Kind == dockerTopKindSidecar {
+				health = "unknown"
+				t.mu.Lock()
+				if h, ok := t.healths[row.ID]; ok {
+					health = h
+				}
+				t.mu.Unlock()
+			}
+		case "restarting":
+			health = "restarting"
+		}
+	}
+	if row.Restarts > 0 {
+		health = fmt.Sprintf("%s, %d restarts", health, row.Restarts)
+	}
+	return health
+}
+
+// runProbe starts the tools container sidecars are probed from.
+func (r *DockerRuntime) runProbe(ctx context.Context) (string, error) {
+	name := r.renderName(r.config.Tools.Name, map[string]interface{}{"Suffix": strconv.FormatInt(time.Now().UnixNano(), 10)})
+	if err := r.runContainer(ctx, DockerRuntimeRunContainerOptions{
+		Name:    name,
+		Image:   r.config.Tools.Image,
+		Cmd:     r.config.Tools.Cmd,
+		Network: DefaultDockerRuntimeTopProbeNetwork,
+		Labels: r.labels(map[string]string{
+			"kess-tools": "",
+		}),
+	}); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// probeSidecar queries the healthz endpoint of daprd in the background, the
+// result shows up in the next refresh.
+func (t *dockerTop) probeSidecar(ctx context.Context, info types.ContainerJSON) {
+	if t.probe == "" {
+		return
+	}
+	t.mu.Lock()
+	if t.probing[info.ID] {
+		t.mu.Unlock()
+		return
+	}
+	t.probing[info.ID] = true
+	t.mu.Unlock()
+
+	go func() {
+		health := "unreachable"
+		if address, err := t.sidecarAddress(ctx, info); err == nil {
+			ctx, cancel := context.WithTimeout(ctx, DefaultDockerRuntimeTopProbeTimeout)
+			defer cancel()
+			health = "healthy"
+			if err := t.r.exec(ctx, dockerExecOptions{
+				Container: t.probe,
+				Cmd:       []string{"wget", "-q", "-O", "/dev/null", "-T", "1", "http://" + address + DefaultDockerRuntimeTopHealthzPath},
+				Stdout:    ioutil.Discard,
+				Stderr:    ioutil.Discard,
+			}); err != nil {
+				health = "unhealthy"
+			}
+		}
+		t.mu.Lock()
+		t.healths[info.ID] = health
+		delete(t.probing, info.ID)
+		t.mu.Unlock()
+	}()
+}
+
+// sidecarAddress is the address daprd serves HTTP on as seen from the host
+// network: localhost for hybrid sidecars, the address of the app container
+// for sidecars sharing its network.
+func (t *dockerTop) sidecarAddress(ctx context.Context, info types.ContainerJSON) (string, error) {
+	port := DefaultDockerRuntimeTopDaprHTTPPort
+	for i, arg := range info.Args {
+		if arg == "--dapr-http-port" && i+1 < len(info.Args) {
+			port = info.Args[i+1]
+		}
+	}
+
+	mode := info.HostConfig.NetworkMode
+	if mode.IsHost() {
+		return net.JoinHostPort("127.0.0.1", port), nil
+	}
+	if mode.IsContainer() {
+		app, err := t.r.client.ContainerInspect(ctx, mode.ConnectedContainer())
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+		info = app
+	}
+	for _, network := range info.NetworkSettings.Networks {
+		if network.IPAddress != "" {
+			return net.JoinHostPort(network.IPAddress, port), nil
+		}
+	}
+	return "", errors.Errorf("Container %s has no address", info.Name)
+}
+
+// watchStats keeps the latest stats of the container from the stats stream
+// until the container goes away.
+func (t *dockerTop) watchStats(ctx context.Context, id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.cancels[id]; ok {
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	t.cancels[id] = cancel
+
+	go func() {
+		defer func() {
+			cancel()
+			t.mu.Lock()
+			delete(t.cancels, id)
+			t.mu.Unlock()
+		}()
+		resp, err := t.r.client.ContainerStats(ctx, id, true)
+		if err != nil {
+			return
+		}
+		defer resp.Body.Close()
+		decoder := json.NewDecoder(resp.Body)
+		for {
+			var s types.StatsJSON
+			if err := decoder.Decode(&s); err != nil {
+				return
+			}
+			stats := &dockerTopStats{Mem: memoryUsage(s.MemoryStats), MemLimit: s.MemoryStats.Limit}
+			cpuDelta := float64(s.CPUStats.CPUUsage.TotalUsage) - float64(s.PreCPUStats.CPUUsage.TotalUsage)
+			systemDelta := float64(s.CPUStats.SystemUsage) - float64(s.PreCPUStats.SystemUsage)
+			cpus := float64(s.CPUStats.OnlineCPUs)
+			if cpus == 0 {
+				cpus = float64(len(s.CPUStats.CPUUsage.PercpuUsage))
+			}
+			if cpuDelta > 0 && systemDelta > 0 {
+				stats.CPU = cpuDelta / systemDelta * cpus * 100
+			}
+			for _, network := range s.Networks {
+				stats.RxBytes += network.RxBytes
+				stats.TxBytes += network.TxBytes
+			}
+			t.mu.Lock()
+			t.stats[id] = stats
+			t.mu.Unlock()
+		}
+	}()
+}
+
+func (t *dockerTop) tailLogs(ctx context.Context, id string) []string {
+	lines := t.options.LogLines
+	if t.expanded {
+		if ws, err := term.GetWinsize(os.Stdout.Fd()); err == nil {
+			lines = int(ws.Height) - len(t.rows) - 6
+		}
+	}
+	if lines < 1 {
+		lines = 1
+	}
+	reader, tty, err := t.r.containerLogs(ctx, id, RuntimeLogsOptions{Tail: fmt.Sprint(lines)}, false)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	defer reader.Close()
+	var buf bytes.Buffer
+	if tty {
+		io.Copy(&buf, reader)
+	} else {
+		stdcopy.StdCopy(&buf, &buf, reader)
+	}
+	text := strings.TrimRight(buf.String(), "\n")
+	if text == "" {
+		return nil
+	}
+	text = strings.NewReplacer("\r", "", "\t", "    ").Replace(text)
+	return strings.Split(text, "\n")
+}
+
+// memoryUsage leaves out the page cache like docker stats does, the key
+// differs between cgroup v1 and v2.
+func memoryUsage(stats types.MemoryStats) uint64 {
+	for _, key := range []string{"total_inactive_file", "inactive_file"} {
+		if v, ok := stats.Stats[key]; ok && v < stats.Usage {
+			return stats.Usage - v
+		}
+	}
+	return stats.Usage
+}
+
+func (t *dockerTop) draw() {
+	width, height := 120, 40
+	if ws, err := term.GetWinsize(os.Stdout.Fd()); err == nil {
+		width, height = int(ws.Width), int(ws.Height)
+	}
+
+	lines := []string{
+		fmt.Sprintf("\x1b[1mkess docker top\x1b[0m  %s  q quit  j/k select  r restart  l logs  e exec  d remove", time.Now().Format("15:04:05")),
+		"",
+		fmt.Sprintf("%-32s %-18s %-10s %-18s %7s %22s %22s", "NAME", "KIND", "STATE", "HEALTH", "CPU %", "MEM USAGE / LIMIT", "NET I/O"),
+	}
+	t.mu.Lock()
+	for i, row := range t.rows {
+		cpu, mem, net := "-", "-", "-"
+		if stats, ok := t.stats[row.ID]; ok && row.State == "running" {
+			cpu = fmt.Sprintf("%.2f", stats.CPU)
+			mem = fmt.Sprintf("%s / %s", units.BytesSize(float64(stats.Mem)), units.BytesSize(float64(stats.MemLimit)))
+			net = fmt.Sprintf("%s / %s", units.BytesSize(float64(stats.RxBytes)), units.BytesSize(float64(stats.TxBytes)))
+		}
+		line := truncate(fmt.Sprintf("%-32s %-18s %-10s %-18s %7s %22s %22s", row.Name, row.Kind, row.State, row.Health, cpu, mem, net), width)
+		if i == t.selected {
+			line = "\x1b[7m" + line + "\x1b[0m"
+		}
+		lines = append(lines, line)
+	}
+	t.mu.Unlock()
+
+	lines = append(lines, "")
+	if row := t.current(); row != nil {
+		lines = append(lines, fmt.Sprintf("\x1b[1mLogs of %s\x1b[0m", row.Name))
+	}
+	for _, line := range t.logs {
+		lines = append(lines, truncate(line, width))
+	}
+
+	if len(lines) > height-1 {
+		lines = lines[:height-1]
+	}
+	var buf bytes.Buffer
+	buf.WriteString("\x1b[H")
+	for _, line := range lines {
+		buf.WriteString(line + "\x1b[K\r\n")
+	}
+	buf.WriteString("\x1b[J")
+	fmt.Fprintf(&buf, "\x1b[%d;1H\x1b[7m%s\x1b[0m", height, truncate(fmt.Sprintf("%-*s", width, t.message), width))
+	os.Stdout.Write(buf.Bytes())
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) > width {
+		return string(runes[:width])
+	}
+	return s
+}
@@ -0,0 +1,248 @@
+package runtimes
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
+	"github.com/yamajik/kess/dapr"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	DockerRuntimeTracingZipkin = "zipkin"
+	DockerRuntimeTracingOtel   = "otel"
+	DockerRuntimeTracingNone   = "none"
+
+	DockerRuntimeOtelExporterConsole = "console"
+	DockerRuntimeOtelExporterFile    = "file"
+)
+
+var (
+	DefaultDockerRuntimeTracing = DockerRuntimeTracingZipkin
+
+	DefaultDockerRuntimeOtelName         = "kess-system-otel"
+	DefaultDockerRuntimeOtelImage        = "otel/opentelemetry-collector-contrib:latest"
+	DefaultDockerRuntimeOtelNetwork      = DefaultDockerRuntimeNetwork
+	DefaultDockerRuntimeOtelPorts        = []string{"50007:4317", "50008:4318"}
+	DefaultDockerRuntimeOtelExternalHost = "localhost:50007"
+	DefaultDockerRuntimeOtelInternalHost = "kess-system-otel:4317"
+	DefaultDockerRuntimeOtelVolumes      = []string{"kess-configs:/kess-configs", "kess-traces:/kess-traces"}
+	DefaultDockerRuntimeOtelConfigFile   = "otel-collector.yaml"
+	DefaultDockerRuntimeOtelExporter     = DockerRuntimeOtelExporterConsole
+	DefaultDockerRuntimeOtelFile         = "/kess-traces/traces.json"
+	// The collector image runs as a non-root user, which can not write to a
+	// fresh volume otherwise.
+	DefaultDockerRuntimeOtelUser = "0"
+)
+
+type DockerRuntimeOtelConfig struct {
+	Name         string
+	Image        string
+	Network      string
+	Ports        []string
+	ExternalHost string
+	InternalHost string
+	Volumes      []string
+	Exporter     string
+	File         string
+}
+
+func (c *DockerRuntimeOtelConfig) Default() error {
+	if c.Name == "" {
+		c.Name = DefaultDockerRuntimeOtelName
+	}
+	if c.Image == "" {
+		c.Image = DefaultDockerRuntimeOtelImage
+	}
+	if c.Network == "" {
+		c.Network = DefaultDockerRuntimeOtelNetwork
+	}
+	if len(c.Ports) == 0 {
+		c.Ports = DefaultDockerRuntimeOtelPorts
+	}
+	if c.ExternalHost == "" {
+		c.ExternalHost = DefaultDockerRuntimeOtelExternalHost
+	}
+	if c.InternalHost == "" {
+		c.InternalHost = DefaultDockerRuntimeOtelInternalHost
+	}
+	if len(c.Volumes) == 0 {
+		c.Volumes = DefaultDockerRuntimeOtelVolumes
+	}
+	if c.Exporter == "" {
+		c.Exporter = DefaultDockerRuntimeOtelExporter
+	}
+	if c.Exporter != DockerRuntimeOtelExporterConsole && c.Exporter != DockerRuntimeOtelExporterFile {
+		return errors.Errorf("Unknown otel exporter: %s", c.Exporter)
+	}
+	if c.File == "" {
+		c.File = DefaultDockerRuntimeOtelFile
+	}
+	return nil
+}
+
+func validateTracing(tracing string) error {
+	switch tracing {
+	case DockerRuntimeTracingZipkin, DockerRuntimeTracingOtel, DockerRuntimeTracingNone:
+		return nil
+	default:
+		return errors.Errorf("Unknown tracing: %s", tracing)
+	}
+}
+
+func (r *DockerRuntime) getTracing(external bool) dapr.ConfigurationSpecTracing {
+	switch r.config.Tracing {
+	case DockerRuntimeTracingZipkin:
+		host := r.config.Zipkin.InternalHost
+		if external {
+			host = r.config.Zipkin.ExternalHost
+		}
+		return dapr.ConfigurationSpecTracing{
+			SamplingRate: "1",
+			Zipkin: dapr.ConfigurationSpecTracingZipkin{
+				EndpointAddress: fmt.Sprintf("http://%s/api/v2/spans", host),
+			},
+		}
+	case DockerRuntimeTracingOtel:
+		host := r.config.Otel.InternalHost
+		if external {
+			host = r.config.Otel.ExternalHost
+		}
+		return dapr.ConfigurationSpecTracing{
+			SamplingRate: "1",
+			Otel: dapr.ConfigurationSpecTracingOtel{
+				EndpointAddress: host,
+				Protocol:        "grpc",
+			},
+		}
+	default:
+		return dapr.ConfigurationSpecTracing{SamplingRate: "0"}
+	}
+}
+
+// runTracing starts the system container receiving the traces of the
+// sidecars, the collector config is written to the configs volume first.
+func (r *DockerRuntime) runTracing(ctx context.Context) error {
+	switch r.config.Tracing {
+	case DockerRuntimeTracingZipkin:
+		return r.runContainer(ctx, DockerRuntimeRunContainerOptions{
+			Name:    r.config.Zipkin.Name,
+			Image:   r.config.Zipkin.Image,
+			Cmd:     r.config.Zipkin.Cmd,
+			Network: r.config.Zipkin.Network,
+			Ports:   r.config.Zipkin.Ports,
+			Labels: r.labels(map[string]string{
+				"kess-system": "zipkin",
+			}),
+		})
+	case DockerRuntimeTracingOtel:
+		configsVolume := r.findConfigsVolume(r.config.Otel.Volumes)
+		if configsVolume == "" {
+			return errors.New("The otel collector needs the configs volume")
+		}
+		buf, err := r.getOtelConfig()
+		if err != nil {
+			return err
+		}
+		if err := r.writeToVolume(ctx, configsVolume, DefaultDockerRuntimeOtelConfigFile, buf); err != nil {
+			return err
+		}
+		for _, volume := range r.config.Otel.Volumes {
+			if err := r.createVolume(ctx, strings.SplitN(volume, ":", 2)[0]); err != nil {
+				return err
+			}
+		}
+		return r.runContainer(ctx, DockerRuntimeRunContainerOptions{
+			Name:    r.config.Otel.Name,
+			Image:   r.config.Otel.Image,
+			Cmd:     []string{"--config", r.volumePath(configsVolume, DefaultDockerRuntimeOtelConfigFile)},
+			Network: r.config.Otel.Network,
+			Ports:   r.config.Otel.Ports,
+			Volumes: r.config.Otel.Volumes,
+			User:    DefaultDockerRuntimeOtelUser,
+			Labels: r.labels(map[string]string{
+				"kess-system": "otel",
+			}),
+		})
+	default:
+		return nil
+	}
+}
+
+func (r *DockerRuntime) getOtelConfig() ([]byte, error) {
+	exporter := map[string]interface{}{"verbosity": "detailed"}
+	exporterName := "debug"
+	if r.config.Otel.Exporter == DockerRuntimeOtelExporterFile {
+		exporter = map[string]interface{}{"path": r.config.Otel.File}
+		exporterName = "file"
+	}
+	config := map[string]interface{}{
+		"receivers": map[string]interface{}{
+			"otlp": map[string]interface{}{
+				"protocols": map[string]interface{}{
+					"grpc": map[string]interface{}{"endpoint": "0.0.0.0:4317"},
+					"http": map[string]interface{}{"endpoint": "0.0.0.0:4318"},
+				},
+			},
+		},
+		"exporters": map[string]interface{}{
+			exporterName: exporter,
+		},
+		"service": map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"traces": map[string]interface{}{
+					"receivers": []string{"otlp"},
+					"exporters": []string{exporterName},
+				},
+			},
+		},
+	}
+	buf, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return buf, nil
+}
+
+// loadTracing finds out which tracing system install picked from the
+// running system containers.
+func (r *DockerRuntime) loadTracing(ctx context.Context) error {
+	for _, c := range []struct {
+		name    string
+		tracing string
+	}{
+		{r.config.Otel.Name, DockerRuntimeTracingOtel},
+		{r.config.Zipkin.Name, DockerRuntimeTracingZipkin},
+	} {
+		if _, err := r.client.ContainerInspect(ctx, c.name); err == nil {
+			r.config.Tracing = c.tracing
+			return nil
+		} else if !client.IsErrNotFound(err) {
+			return errors.WithStack(err)
+		}
+	}
+	r.config.Tracing = DockerRuntimeTracingNone
+	return nil
+}
+
+func (r *DockerRuntime) volumePath(volume string, name string) string {
+	return path.Join(strings.SplitN(volume, ":", 2)[1], name)
+}
+
+func (r *DockerRuntime) writeToVolume(ctx context.Context, volume string, name string, buf []byte) error {
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	if err := writeTarBytes(tw, name, buf); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	return r.copyToVolume(ctx, volume, &archive)
+}
@@ -0,0 +1,378 @@
+package runtimes
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+	"github.com/yamajik/kess/dapr"
+	"golang.org/x/net/websocket"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	DefaultDockerRuntimeUIPort     = 8001
+	DefaultDockerRuntimeUILogsTail = "200"
+	// Requests changing containers must carry this header, which a page of
+	// another origin can not send without a preflight the UI never answers.
+	DefaultDockerRuntimeUIHeader = "X-Kess-UI"
+)
+
+type dockerUIContainer struct {
+	Name      string   `json:"name"`
+	Component string   `json:"component,omitempty"`
+	Image     string   `json:"image"`
+	State     string   `json:"state"`
+	Status    string   `json:"status"`
+	Ports     []string `json:"ports,omitempty"`
+}
+
+type dockerUIApp struct {
+	AppID   string             `json:"appId"`
+	App     *dockerUIContainer `json:"app,omitempty"`
+	Sidecar *dockerUIContainer `json:"sidecar,omitempty"`
+}
+
+type dockerUIComponent struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	File string `json:"file"`
+}
+
+// UI serves the kess web UI until the context is done.
+func (r *DockerRuntime) UI(ctx context.Context, options RuntimeUIOptions) error {
+	if options.Port == 0 {
+		options.Port = DefaultDockerRuntimeUIPort
+	}
+	if err := r.loadTracing(ctx); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", options.Port))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	server := &http.Server{Handler: uiCheckHost(r.uiHandler(), options.Port)}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	url := fmt.Sprintf("http://localhost:%d", options.Port)
+	print.SuccessStatusEvent(os.Stdout, "Kess UI running on %s", url)
+	if options.Open {
+		if err := dapr.OpenBrowser(url); err != nil {
+			print.WarningStatusEvent(os.Stdout, "Failed to open the browser: %s", err)
+		}
+	}
+
+	if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		return errors.WithStack(err)
+	}
+	return ctx.Err()
+}
+
+func (r *DockerRuntime) uiHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/" {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, dockerUIIndex)
+	})
+	mux.HandleFunc("/api/system", func(w http.ResponseWriter, req *http.Request) {
+		containers, err := r.uiSystem(req.Context())
+		uiWriteJSON(w, containers, err)
+	})
+	mux.HandleFunc("/api/apps", func(w http.ResponseWriter, req *http.Request) {
+		apps, err := r.uiApps(req.Context())
+		uiWriteJSON(w, apps, err)
+	})
+	mux.HandleFunc("/api/apps/", r.uiApp)
+	mux.HandleFunc("/api/components", func(w http.ResponseWriter, req *http.Request) {
+		components, err := r.uiComponents(req.Context())
+		uiWriteJSON(w, components, err)
+	})
+	mux.HandleFunc("/api/traces", r.uiTraces)
+	return mux
+}
+
+func (r *DockerRuntime) uiSystem(ctx context.Context) ([]dockerUIContainer, error) {
+	containers, err := r.client.ContainerList(ctx, types.ContainerListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", "kess-system")),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	result := []dockerUIContainer{}
+	for _, c := range containers {
+		result = append(result, uiContainer(c, c.Labels["kess-system"]))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func (r *DockerRuntime) uiApps(ctx context.Context) ([]*dockerUIApp, error) {
+	containers, err := r.client.ContainerList(ctx, types.ContainerListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", "kess-app")),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	apps := map[string]*dockerUIApp{}
+	result := []*dockerUIApp{}
+	for _, c := range containers {
+		appID := c.Labels["kess-app"]
+		app, ok := apps[appID]
+		if !ok {
+			app = &dockerUIApp{AppID: appID}
+			apps[appID] = app
+			result = append(result, app)
+		}
+		container := uiContainer(c, "")
+		if _, ok := c.Labels["kess-app-sidecar"]; ok {
+			app.Sidecar = &container
+		} else {
+			app.App = &container
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].AppID < result[j].AppID })
+	return result, nil
+}
+
+func (r *DockerRuntime) uiComponents(ctx context.Context) ([]dockerUIComponent, error) {
+	configsVolume := r.findConfigsVolume(r.config.Ingress.Volumes)
+	if configsVolume == "" {
+		return nil, errors.New("No configs volume")
+	}
+	files, err := r.readDirFromVolume(ctx, configsVolume, dapr.DefaultDaprComponentsDirname)
+	if err != nil {
+		return nil, err
+	}
+	result := []dockerUIComponent{}
+	for name, buf := range files {
+		component := dapr.Component{}
+		if err := yaml.Unmarshal(buf, &component); err != nil {
+			return nil, errors.Wrapf(err, "Error parsing component %s", name)
+		}
+		result = append(result, dockerUIComponent{Name: component.Metadata.Name, Type: component.Spec.Type, File: name})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// uiApp serves /api/apps/<AppID>/<action> for the actions of one app.
+func (r *DockerRuntime) uiApp(w http.ResponseWriter, req *http.Request) {
+	parts := strings.SplitN(strings.TrimPrefix(req.URL.Path, "/api/apps/"), "/", 3)
+	if len(parts) < 2 || parts[0] == "" {
+		http.NotFound(w, req)
+		return
+	}
+	appID, action := parts[0], parts[1]
+	m := map[string]interface{}{"AppID": appID}
+
+	if action == "logs" {
+		name := r.renderName(r.config.App.Name, m)
+		if req.URL.Query().Get("source") == "sidecar" {
+			name = r.renderName(r.config.Sidecar.Name, m)
+		}
+		websocket.Server{
+			Handshake: uiCheckOrigin,
+			Handler:   func(ws *websocket.Conn) { r.uiLogs(ws, name) },
+		}.ServeHTTP(w, req)
+		return
+	}
+
+	if req.Method != http.MethodPost || req.Header.Get(DefaultDockerRuntimeUIHeader) == "" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := req.Context()
+	switch action {
+	case "restart":
+		uiWriteJSON(w, map[string]string{}, r.restartApp(ctx, appID))
+	case "remove":
+		uiWriteJSON(w, map[string]string{}, r.Remove(ctx, RuntimeRemoveOptions{AppID: appID}))
+	case "invoke":
+		if len(parts) < 3 || parts[2] == "" {
+			http.Error(w, "Missing method", http.StatusBadRequest)
+			return
+		}
+		r.uiInvoke(w, req, appID, parts[2])
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+func (r *DockerRuntime) uiInvoke(w http.ResponseWriter, req *http.Request, appID string, method string) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		uiWriteJSON(w, nil, errors.WithStack(err))
+		return
+	}
+	daprClient, err := dapr.NewClient(&dapr.ClientConfig{Address: dapr.DefaultIngressHTTPAddress})
+	if err != nil {
+		uiWriteJSON(w, nil, err)
+		return
+	}
+	headers := http.Header{}
+	if len(body) > 0 {
+		headers.Set("Content-Type", "application/json")
+	}
+	resp, err := daprClient.Do(req.Context(), dapr.ClientRequest{
+		Method:  http.MethodPost,
+		Path:    fmt.Sprintf("/v1.0/invoke/%s/method/%s", appID, method),
+		Headers: headers,
+		Body:    body,
+	})
+	if err != nil {
+		uiWriteJSON(w, nil, err)
+		return
+	}
+	w.Header().Set("Content-Type", resp.Headers.Get("Content-Type"))
+	w.WriteHeader(resp.StatusCode)
+	w.Write(resp.Body)
+}
+
+// uiLogs follows the logs of the container and sends them line by line
+// until the page closes the socket.
+func (r *DockerRuntime) uiLogs(ws *websocket.Conn, name string) {
+	defer ws.Close()
+	ctx, cancel := context.WithCancel(ws.Request().Context())
+	defer cancel()
+	go func() {
+		io.Copy(ioutil.Discard, ws)
+		cancel()
+	}()
+
+	reader, tty, err := r.containerLogs(ctx, name, RuntimeLogsOptions{Follow: true, Tail: DefaultDockerRuntimeUILogsTail}, false)
+	if err != nil {
+		websocket.Message.Send(ws, err.Error())
+		return
+	}
+	defer reader.Close()
+
+	pr, pw := io.Pipe()
+	go func() {
+		var err error
+		if tty {
+			_, err = io.Copy(pw, reader)
+		} else {
+			_, err = stdcopy.StdCopy(pw, pw, reader)
+		}
+		pw.CloseWithError(err)
+	}()
+
+	buffered := bufio.NewReader(pr)
+	for {
+		line, err := buffered.ReadString('\n')
+		if line == "" && err != nil {
+			return
+		}
+		if err := websocket.Message.Send(ws, strings.TrimRight(line, "\r\n")); err != nil {
+			return
+		}
+	}
+}
+
+// uiTraces passes the trace query through to the Zipkin kess installs.
+func (r *DockerRuntime) uiTraces(w http.ResponseWriter, req *http.Request) {
+	if r.config.Tracing != DockerRuntimeTracingZipkin {
+		uiWriteJSON(w, nil, errors.New("Zipkin is not installed"))
+		return
+	}
+	query := url.Values{}
+	query.Set("endTs", strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10))
+	query.Set("lookback", strconv.FormatInt(int64(dapr.DefaultTracesSince/time.Millisecond), 10))
+	query.Set("limit", strconv.Itoa(dapr.DefaultTracesLimit))
+	if service := req.URL.Query().Get("service"); service != "" {
+		query.Set("serviceName", service)
+	}
+	u := url.URL{Scheme: "http", Host: r.config.Zipkin.ExternalHost, Path: "/api/v2/traces", RawQuery: query.Encode()}
+	request, err := http.NewRequestWithContext(req.Context(), http.MethodGet, u.String(), nil)
+	if err != nil {
+		uiWriteJSON(w, nil, errors.WithStack(err))
+		return
+	}
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		uiWriteJSON(w, nil, errors.Wrap(err, "Error querying Zipkin"))
+		return
+	}
+	defer resp.Body.Close()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
+}
+
+func uiContainer(c types.Container, component string) dockerUIContainer {
+	container := dockerUIContainer{
+		Name:      strings.TrimPrefix(c.Names[0], "/"),
+		Component: component,
+		Image:     c.Image,
+		State:     c.State,
+		Status:    c.Status,
+	}
+	for _, port := range c.Ports {
+		if port.PublicPort != 0 {
+			container.Ports = append(container.Ports, fmt.Sprintf("%d:%d", port.PublicPort, port.PrivatePort))
+		}
+	}
+	return container
+}
+
+// uiCheckHost rejects requests for any host but the UI's own, a page whose
+// domain rebinds to 127.0.0.1 would otherwise be of the same origin.
+func uiCheckHost(handler http.Handler, port int) http.Handler {
+	hosts := map[string]bool{
+		fmt.Sprintf("localhost:%d", port): true,
+		fmt.Sprintf("127.0.0.1:%d", port): true,
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !hosts[strings.ToLower(req.Host)] {
+			http.Error(w, "Forbidden host", http.StatusForbidden)
+			return
+		}
+		handler.ServeHTTP(w, req)
+	})
+}
+
+func uiCheckOrigin(config *websocket.Config, req *http.Request) error {
+	origin, err := websocket.Origin(config, req)
+	if err != nil {
+		return err
+	}
+	if origin == nil || origin.Host != req.Host {
+		return errors.New("Cross origin websocket")
+	}
+	return nil
+}
+
+func uiWriteJSON(w http.ResponseWriter, value interface{}, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		value = map[string]string{"error": err.Error()}
+	}
+	json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,233 @@
+package runtimes
+
+// dockerUIIndex is the whole kess UI, served as is to keep the binary free of
+// an asset pipeline.
+var dockerUIIndex = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>kess</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; color: #1f2328; background: #f6f8fa; }
+header { background: #24292f; color: #fff; padding: 12px 24px; font-size: 20px; font-weight: 600; }
+main { padding: 16px 24px; display: grid; gap: 16px; }
+section { background: #fff; border: 1px solid #d0d7de; border-radius: 6px; padding: 12px 16px; }
+h2 { font-size: 16px; margin: 0 0 8px; display: flex; justify-content: space-between; align-items: center; }
+table { border-collapse: collapse; width: 100%; font-size: 13px; }
+th, td { text-align: left; padding: 4px 8px; border-bottom: 1px solid #eaeef2; }
+th { color: #57606a; font-weight: 600; }
+button { font-size: 12px; padding: 2px 8px; margin-right: 4px; cursor: pointer; }
+pre { background: #0d1117; color: #c9d1d9; padding: 8px; height: 320px; overflow: auto; font-size: 12px; margin: 0; white-space: pre-wrap; }
+input, textarea, select { font-family: monospace; font-size: 12px; }
+textarea { width: 100%; height: 60px; }
+.running { color: #1a7f37; }
+.exited, .dead { color: #cf222e; }
+.error { color: #cf222e; font-size: 13px; }
+.muted { color: #57606a; }
+</style>
+</head>
+<body>
+<header>kess</header>
+<main>
+<section>
+  <h2>System</h2>
+  <table id="system"></table>
+</section>
+<section>
+  <h2>Apps</h2>
+  <table id="apps"></table>
+</section>
+<section>
+  <h2><span>Logs <span id="logs-title" class="muted"></span></span><button onclick="closeLogs()">Close</button></h2>
+  <pre id="logs"></pre>
+</section>
+<section>
+  <h2>Invoke</h2>
+  <div>
+    <select id="invoke-app"></select>
+    <input id="invoke-method" placeholder="method">
+    <button onclick="invoke()">Invoke</button>
+  </div>
+  <textarea id="invoke-body" placeholder="JSON body"></textarea>
+  <pre id="invoke-result" style="height: 120px"></pre>
+</section>
+<section>
+  <h2><span>Components</span><button onclick="loadComponents()">Refresh</button></h2>
+  <table id="components"></table>
+</section>
+<section>
+  <h2><span>Traces</span><button onclick="loadTraces()">Refresh</button></h2>
+  <table id="traces"></table>
+</section>
+</main>
+<script>
+var socket = null;
+
+function el(tag, text, cls) {
+  var e = document.createElement(tag);
+  if (text !== undefined) e.textContent = text;
+  if (cls) e.className = cls;
+  return e;
+}
+
+function row(table, cells, header) {
+  var tr = el("tr");
+  cells.forEach(function (cell) {
+    if (cell instanceof Node) {
+      var td = el(header ? "th" : "td");
+      td.appendChild(cell);
+      tr.appendChild(td);
+    } else {
+      tr.appendChild(el(header ? "th" : "td", cell === undefined ? "" : String(cell)));
+    }
+  });
+  table.appendChild(tr);
+}
+
+function button(text, onclick) {
+  var b = el("button", text);
+  b.onclick = onclick;
+  return b;
+}
+
+function buttons() {
+  var span = el("span");
+  Array.prototype.slice.call(arguments).forEach(function (b) { span.appendChild(b); });
+  return span;
+}
+
+function state(c) {
+  return c ? el("span", c.status, c.state) : el("span", "missing", "exited");
+}
+
+function get(path) {
+  return fetch(path).then(function (resp) {
+    return resp.json().then(function (body) {
+      if (!resp.ok) throw new Error(body.error || resp.statusText);
+      return body;
+    });
+  });
+}
+
+function post(path, body) {
+  return fetch(path, { method: "POST", headers: { "X-Kess-UI": "1" }, body: body });
+}
+
+function failed(table, err) {
+  table.innerHTML = "";
+  row(table, [el("span", err.message, "error")]);
+}
+
+function loadSystem() {
+  var table = document.getElementById("system");
+  get("/api/system").then(function (containers) {
+    table.innerHTML = "";
+    row(table, ["Component", "Container", "Image", "State", "Ports"], true);
+    containers.forEach(function (c) {
+      row(table, [c.component, c.name, c.image, state(c), (c.ports || []).join(", ")]);
+    });
+  }).catch(function (err) { failed(table, err); });
+}
+
+function loadApps() {
+  var table = document.getElementById("apps");
+  var select = document.getElementById("invoke-app");
+  get("/api/apps").then(function (apps) {
+    table.innerHTML = "";
+    row(table, ["App ID", "Image", "App", "Sidecar", ""], true);
+    var selected = select.value;
+    select.innerHTML = "";
+    apps.forEach(function (app) {
+      row(table, [app.appId, app.app ? app.app.image : "", state(app.app), state(app.sidecar), buttons(
+        button("Logs", function () { openLogs(app.appId, "app"); }),
+        button("Sidecar logs", function () { openLogs(app.appId, "sidecar"); }),
+        button("Restart", function () { action(app.appId, "restart"); }),
+        button("Remove", function () {
+          if (confirm("Remove " + app.appId + "?")) action(app.appId, "remove");
+        })
+      )]);
+      var option = el("option", app.appId);
+      option.value = app.appId;
+      select.appendChild(option);
+    });
+    if (selected) select.value = selected;
+  }).catch(function (err) { failed(table, err); });
+}
+
+function action(appId, name) {
+  post("/api/apps/" + encodeURIComponent(appId) + "/" + name).then(function (resp) {
+    if (!resp.ok) return resp.json().then(function (body) { alert(body.error); });
+  }).then(loadApps);
+}
+
+function openLogs(appId, source) {
+  closeLogs();
+  var pre = document.getElementById("logs");
+  document.getElementById("logs-title").textContent = appId + " " + source;
+  var scheme = location.protocol === "https:" ? "wss://" : "ws://";
+  socket = new WebSocket(scheme + location.host + "/api/apps/" + encodeURIComponent(appId) + "/logs?source=" + source);
+  socket.onmessage = function (event) {
+    var follow = pre.scrollTop + pre.clientHeight >= pre.scrollHeight - 4;
+    pre.appendChild(document.createTextNode(event.data + "\n"));
+    if (follow) pre.scrollTop = pre.scrollHeight;
+  };
+}
+
+function closeLogs() {
+  if (socket) socket.close();
+  socket = null;
+  document.getElementById("logs").textContent = "";
+  document.getElementById("logs-title").textContent = "";
+}
+
+function invoke() {
+  var appId = document.getElementById("invoke-app").value;
+  var method = document.getElementById("invoke-method").value;
+  var result = document.getElementById("invoke-result");
+  if (!appId || !method) return;
+  result.textContent = "...";
+  post("/api/apps/" + encodeURIComponent(appId) + "/invoke/" + method, document.getElementById("invoke-body").value).then(function (resp) {
+    return resp.text().then(function (text) {
+      result.textContent = resp.status + " " + resp.statusText + "\n\n" + text;
+    });
+  }).catch(function (err) { result.textContent = err.message; });
+}
+
+function loadComponents() {
+  var table = document.getElementById("components");
+  get("/api/components").then(function (components) {
+    table.innerHTML = "";
+    row(table, ["Name", "Type", "File"], true);
+    components.forEach(function (c) { row(table, [c.name, c.type, c.file]); });
+  }).catch(function (err) { failed(table, err); });
+}
+
+function loadTraces() {
+  var table = document.getElementById("traces");
+  get("/api/traces").then(function (traces) {
+    table.innerHTML = "";
+    row(table, ["Time", "Trace ID", "Service", "Name", "Duration", "Spans"], true);
+    traces.forEach(function (spans) {
+      if (!spans.length) return;
+      var root = spans.filter(function (s) { return !s.parentId; })[0] || spans[0];
+      row(table, [
+        new Date(root.timestamp / 1000).toLocaleTimeString(),
+        root.traceId,
+        root.localEndpoint ? root.localEndpoint.serviceName : "",
+        root.name,
+        (root.duration / 1000).toFixed(1) + "ms",
+        spans.length
+      ]);
+    });
+  }).catch(function (err) { failed(table, err); });
+}
+
+loadSystem();
+loadApps();
+loadComponents();
+loadTraces();
+setInterval(function () { loadSystem(); loadApps(); }, 5000);
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,168 @@
+package runtimes
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
+	"github.com/yamajik/kess/dapr"
+)
+
+var (
+	DefaultDockerRuntimeUpgradeOldSuffix = "-old"
+)
+
+func (r *DockerRuntime) Upgrade(ctx context.Context, options RuntimeUpgradeOptions) error {
+	version, err := dapr.ResolveRuntimeVersion(options.RuntimeVersion)
+	if err != nil {
+		return err
+	}
+	r.config.RuntimeVersion = version
+
+	print.InfoStatusEvent(os.Stdout, "Upgrading Dapr binaries to %s", version)
+	if err := dapr.StandaloneUpgrade(version, options.DashboardVersion); err != nil {
+		return err
+	}
+
+	if err := r.loadTracing(ctx); err != nil {
+		return err
+	}
+	externalDaprConfigs := r.getDaprConfigs(r.getTracing(true), r.config.Redis.ExternalHost, r.config.Redis.Password, dapr.DefaultSecretsFilePath())
+	if err := externalDaprConfigs.Save(); err != nil {
+		return err
+	}
+	if err := dapr.EnsureLocalSecrets(dapr.DefaultSecretsFilePath()); err != nil {
+		return err
+	}
+
+	if err := r.recreateContainer(ctx, r.config.Placement.Name, r.image(r.config.Placement.Image)); err != nil {
+		return err
+	}
+
+	if err := r.recreateContainer(ctx, r.config.Ingress.Name, r.image(r.config.Ingress.Image)); err != nil {
+		return err
+	}
+
+	if options.DashboardVersion != "" {
+		r.config.DashboardVersion = options.DashboardVersion
+		if err := r.recreateContainer(ctx, r.config.Dashboard.Name, r.dashboardImage()); err != nil {
+			return err
+		}
+	}
+
+	sidecars, err := r.client.ContainerList(ctx, types.ContainerListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", "kess-app-sidecar")),
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, sidecar := range sidecars {
+		if err := r.recreateContainer(ctx, strings.TrimPrefix(sidecar.Names[0], "/"), r.image(r.config.Sidecar.Image)); err != nil {
+			return err
+		}
+	}
+
+	print.SuccessStatusEvent(os.Stdout, "Upgraded Dapr runtime to %s", version)
+	return nil
+}
+
+// recreateContainer replaces the container with a new one running the given
+// image, keeping the rest of its configuration.
+func (r *DockerRuntime) recreateContainer(ctx context.Context, name string, image string) error {
+	info, err := r.client.ContainerInspect(ctx, name)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			print.WarningStatusEvent(os.Stdout, "Container %s not found, skipped", name)
+			return nil
+		}
+		return errors.WithStack(err)
+	}
+
+	if info.Config.Image == image {
+		print.InfoStatusEvent(os.Stdout, "Container %s is already running %s", name, image)
+		return nil
+	}
+
+	if err := r.ensureImage(ctx, image); err != nil {
+		return err
+	}
+
+	print.InfoStatusEvent(os.Stdout, "Upgrading container %s to %s", name, image)
+	// Keep the old container aside until the new one runs, so a failed
+	// upgrade can bring it back.
+	old := name + DefaultDockerRuntimeUpgradeOldSuffix
+	if err := r.removeContainer(ctx, old); err != nil {
+		return err
+	}
+	if err := r.client.ContainerRename(ctx, info.ID, old); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := r.client.ContainerStop(ctx, info.ID, nil); err != nil {
+		r.restoreContainer(info, "")
+		return errors.WithStack(err)
+	}
+
+	config := *info.Config
+	config.Image = image
+	config.Labels = map[string]string{}
+	for k, v := range info.Config.Labels {
+		config.Labels[k] = v
+	}
+	config.Labels[DefaultDockerRuntimeVersionLabel] = r.config.RuntimeVersion
+	resp, err := r.client.ContainerCreate(ctx, &config, info.HostConfig, nil, nil, name)
+	if err != nil {
+		r.restoreContainer(info, "")
+		return errors.WithStack(err)
+	}
+
+	if err := r.client.ContainerStart(ctx, resp.ID, types.ContainerStartOptions{}); err != nil {
+		r.restoreContainer(info, resp.ID)
+		return errors.WithStack(err)
+	}
+
+	return r.removeContainer(ctx, old)
+}
+
+// restoreContainer brings back the container a failed upgrade set aside,
+// removing the new one if it was created.
+func (r *DockerRuntime) restoreContainer(info types.ContainerJSON, created string) {
+	name := strings.TrimPrefix(info.Name, "/")
+	ctx := context.Background()
+	if created != "" {
+		if err := r.removeContainer(ctx, created); err != nil {
+			print.WarningStatusEvent(os.Stdout, "Failed to remove container %s: %s", created, err)
+		}
+	}
+	if err := r.client.ContainerRename(ctx, info.ID, name); err != nil {
+		print.WarningStatusEvent(os.Stdout, "Failed to restore container %s: %s", name, err)
+		return
+	}
+	if !info.State.Running {
+		return
+	}
+	if err := r.client.ContainerStart(ctx, info.ID, types.ContainerStartOptions{}); err != nil {
+		print.WarningStatusEvent(os.Stdout, "Failed to restart container %s: %s", name, err)
+	}
+}
+
+// loadRuntimeVersion picks up the runtime version kess was installed with, so
+// sidecars match the system containers.
+func (r *DockerRuntime) loadRuntimeVersion(ctx context.Context) error {
+	info, err := r.client.ContainerInspect(ctx, r.config.Placement.Name)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return nil
+		}
+		return errors.WithStack(err)
+	}
+	if version, ok := info.Config.Labels[DefaultDockerRuntimeVersionLabel]; ok && version != "" {
+		r.config.RuntimeVersion = version
+	}
+	return nil
+}
@@ -70,3 +70,43 @@ func (r *KubernetesRuntime) Logs(ctx context.Context, options RuntimeLogsOptions
 func (r *KubernetesRuntime) Dashboard(ctx context.Context, options RuntimeDashboardOptions) error {
 	return nil
 }
+
+func (r *KubernetesRuntime) BundleSave(ctx context.Context, options RuntimeBundleSaveOptions) error {
+	return errNotSupported("kubernetes", "bundle save")
+}
+
+func (r *KubernetesRuntime) BundleLoad(ctx context.Context, options RuntimeBundleLoadOptions) error {
+	return errNotSupported("kubernetes", "bundle load")
+}
+
+func (r *KubernetesRuntime) Upgrade(ctx context.Context, options RuntimeUpgradeOptions) error {
+	return errNotSupported("kubernetes", "upgrade")
+}
+
+func (r *KubernetesRuntime) SecretsSet(ctx context.Context, options RuntimeSecretsSetOptions) error {
+	return errNotSupported("kubernetes", "secrets set")
+}
+
+func (r *KubernetesRuntime) UI(ctx context.Context, options RuntimeUIOptions) error {
+	return errNotSupported("kubernetes", "ui")
+}
+
+func (r *KubernetesRuntime) Top(ctx context.Context, options RuntimeTopOptions) error {
+	return errNotSupported("kubernetes", "top")
+}
+
+func (r *KubernetesRuntime) Exec(ctx context.Context, options RuntimeExecOptions) error {
+	return errNotSupported("kubernetes", "exec")
+}
+
+func (r *KubernetesRuntime) Debug(ctx context.Context, options RuntimeDebugOptions) error {
+	return errNotSupported("kubernetes", "debug")
+}
+
+func (r *KubernetesRuntime) Subscribe(ctx context.Context, options RuntimeSubscribeOptions) error {
+	return errNotSupported("kubernetes", "subscribe")
+}
+
+func (r *KubernetesRuntime) BindingListen(ctx context.Context, options RuntimeBindingListenOptions) error {
+	return errNotSupported("kubernetes", "binding listen")
+}
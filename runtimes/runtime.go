@@ -2,6 +2,7 @@ package runtimes
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/yamajik/kess/dapr"
@@ -14,6 +15,16 @@ type Runtime interface {
 	Remove(ctx context.Context, options RuntimeRemoveOptions) error
 	Logs(ctx context.Context, options RuntimeLogsOptions) error
 	Dashboard(ctx context.Context, options RuntimeDashboardOptions) error
+	BundleSave(ctx context.Context, options RuntimeBundleSaveOptions) error
+	BundleLoad(ctx context.Context, options RuntimeBundleLoadOptions) error
+	Upgrade(ctx context.Context, options RuntimeUpgradeOptions) error
+	SecretsSet(ctx context.Context, options RuntimeSecretsSetOptions) error
+	UI(ctx context.Context, options RuntimeUIOptions) error
+	Top(ctx context.Context, options RuntimeTopOptions) error
+	Exec(ctx context.Context, options RuntimeExecOptions) error
+	Debug(ctx context.Context, options RuntimeDebugOptions) error
+	Subscribe(ctx context.Context, options RuntimeSubscribeOptions) error
+	BindingListen(ctx context.Context, options RuntimeBindingListenOptions) error
 }
 
 type RuntimeConfig struct {
@@ -26,6 +37,10 @@ type RuntimeConfig struct {
 type RuntimeInstallOptions struct {
 	RuntimeVersion   string
 	DashboardVersion string
+	Bundle           string
+	Tracing          string
+	TracingExporter  string
+	Metrics          bool
 }
 
 type RuntimeUninstallOptions struct {
@@ -34,6 +49,7 @@ type RuntimeUninstallOptions struct {
 type RuntimeRunOptions struct {
 	dapr.StandaloneRunConfig
 	AppImage string
+	Hybrid   bool
 }
 
 type RuntimeRemoveOptions struct {
@@ -41,15 +57,77 @@ type RuntimeRemoveOptions struct {
 }
 
 type RuntimeLogsOptions struct {
-	AppID  string
-	Follow bool
-	Tail   string
+	AppID      string
+	Follow     bool
+	Tail       string
+	Since      string
+	Until      string
+	Timestamps bool
+	Sidecar    bool
+	All        bool
+	Pipeline   dapr.LogPipeline
 }
 
 type RuntimeDashboardOptions struct {
 	dapr.DashboardRunConfig
 }
 
+type RuntimeBundleSaveOptions struct {
+	File           string
+	RuntimeVersion string
+}
+
+type RuntimeBundleLoadOptions struct {
+	File string
+}
+
+type RuntimeUpgradeOptions struct {
+	RuntimeVersion   string
+	DashboardVersion string
+}
+
+type RuntimeSecretsSetOptions struct {
+	Key   string
+	Value string
+}
+
+type RuntimeUIOptions struct {
+	Port int
+	Open bool
+}
+
+type RuntimeTopOptions struct {
+	Interval time.Duration
+	LogLines int
+}
+
+type RuntimeExecOptions struct {
+	AppID   string
+	Sidecar bool
+	Tty     bool
+	Cmd     []string
+}
+
+type RuntimeDebugOptions struct {
+	AppID string
+	Tty   bool
+	Cmd   []string
+}
+
+type RuntimeSubscribeOptions struct {
+	dapr.SubscribeConfig
+}
+
+type RuntimeBindingListenOptions struct {
+	dapr.BindingListenConfig
+}
+
+// errNotSupported is returned by runtimes for the commands they have no
+// counterpart for, so the command fails instead of doing nothing.
+func errNotSupported(runtime string, command string) error {
+	return errors.Errorf("%s is not supported by the %s runtime", command, runtime)
+}
+
 func New(config RuntimeConfig) (Runtime, error) {
 	switch config.Type {
 	case "slim":
@@ -40,3 +40,43 @@ func (r *SlimRuntime) Logs(ctx context.Context, options RuntimeLogsOptions) erro
 func (r *SlimRuntime) Dashboard(ctx context.Context, options RuntimeDashboardOptions) error {
 	return nil
 }
+
+func (r *SlimRuntime) BundleSave(ctx context.Context, options RuntimeBundleSaveOptions) error {
+	return errNotSupported("slim", "bundle save")
+}
+
+func (r *SlimRuntime) BundleLoad(ctx context.Context, options RuntimeBundleLoadOptions) error {
+	return errNotSupported("slim", "bundle load")
+}
+
+func (r *SlimRuntime) Upgrade(ctx context.Context, options RuntimeUpgradeOptions) error {
+	return errNotSupported("slim", "upgrade")
+}
+
+func (r *SlimRuntime) SecretsSet(ctx context.Context, options RuntimeSecretsSetOptions) error {
+	return errNotSupported("slim", "secrets set")
+}
+
+func (r *SlimRuntime) UI(ctx context.Context, options RuntimeUIOptions) error {
+	return errNotSupported("slim", "ui")
+}
+
+func (r *SlimRuntime) Top(ctx context.Context, options RuntimeTopOptions) error {
+	return errNotSupported("slim", "top")
+}
+
+func (r *SlimRuntime) Exec(ctx context.Context, options RuntimeExecOptions) error {
+	return errNotSupported("slim", "exec")
+}
+
+func (r *SlimRuntime) Debug(ctx context.Context, options RuntimeDebugOptions) error {
+	return errNotSupported("slim", "debug")
+}
+
+func (r *SlimRuntime) Subscribe(ctx context.Context, options RuntimeSubscribeOptions) error {
+	return errNotSupported("slim", "subscribe")
+}
+
+func (r *SlimRuntime) BindingListen(ctx context.Context, options RuntimeBindingListenOptions) error {
+	return errNotSupported("slim", "binding listen")
+}